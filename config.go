@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"go.xrstf.de/github_exporter/pkg/policy"
+)
+
+// config is the optional JSON configuration file given via -config, for
+// settings that do not fit into CLI flags.
+type config struct {
+	Policies []policy.Policy `json:"policies"`
+}
+
+func loadConfig(filename string) (*config, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &config{}
+	if err := json.Unmarshal(content, cfg); err != nil {
+		return nil, err
+	}
+
+	names := map[string]struct{}{}
+	for i, p := range cfg.Policies {
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("policy %d is invalid: %w", i, err)
+		}
+
+		if _, exists := names[p.Name]; exists {
+			return nil, fmt.Errorf("policy name %q is used more than once", p.Name)
+		}
+
+		names[p.Name] = struct{}{}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/client"
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/sirupsen/logrus"
+)
+
+// repositoryFilter decides which of the repositories of an -owner are
+// included in the exporter.
+type repositoryFilter struct {
+	includeForks    bool
+	includeLocked   bool
+	includeArchived bool
+	includePrivate  bool
+	include         *regexp.Regexp
+	exclude         *regexp.Regexp
+	topics          []string
+}
+
+func (f *repositoryFilter) Matches(owner string, repo client.OwnedRepository) bool {
+	if (repo.IsFork && !f.includeForks) ||
+		(repo.IsLocked && !f.includeLocked) ||
+		(repo.IsArchived && !f.includeArchived) ||
+		(repo.IsPrivate && !f.includePrivate) {
+		return false
+	}
+
+	fullName := fmt.Sprintf("%s/%s", owner, repo.Name)
+
+	if f.include != nil && !f.include.MatchString(fullName) {
+		return false
+	}
+
+	if f.exclude != nil && f.exclude.MatchString(fullName) {
+		return false
+	}
+
+	// if topics are configured, the repository needs to have at least one of them
+	if len(f.topics) > 0 {
+		for _, topic := range repo.Topics {
+			for _, wanted := range f.topics {
+				if strings.EqualFold(topic, wanted) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func (o *options) repositoryFilter() (*repositoryFilter, error) {
+	filter := &repositoryFilter{
+		includeForks:    o.ownerIncludeForks,
+		includeLocked:   o.ownerIncludeLocked,
+		includeArchived: o.ownerIncludeArchived,
+		includePrivate:  o.ownerIncludePrivate,
+		topics:          o.ownerTopics,
+	}
+
+	if o.ownerInclude != "" {
+		include, err := regexp.Compile(o.ownerInclude)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -owner-include: %w", err)
+		}
+
+		filter.include = include
+	}
+
+	if o.ownerExclude != "" {
+		exclude, err := regexp.Compile(o.ownerExclude)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -owner-exclude: %w", err)
+		}
+
+		filter.exclude = exclude
+	}
+
+	return filter, nil
+}
+
+// discoverRepositories returns the names of all repositories of the given
+// owner that match the filter.
+func discoverRepositories(c *client.Client, owner string, filter *repositoryFilter) ([]string, error) {
+	repos, err := c.OwnedRepositories(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, repo := range repos {
+		if filter.Matches(owner, repo) {
+			names = append(names, repo.Name)
+		}
+	}
+
+	return names, nil
+}
+
+type managedRepository struct {
+	repo *github.Repository
+
+	// discovered is true for repositories that were found via -owner;
+	// only those are ever retired.
+	discovered bool
+
+	// cancel stops the refresh workers; it is nil while the repository
+	// is retired.
+	cancel context.CancelFunc
+
+	// missingSince is set when the repository vanished from its owner.
+	missingSince *time.Time
+}
+
+// repositoryManager keeps the set of repositories in the fetcher and
+// collector in sync with what is discovered for the -owner logins.
+type repositoryManager struct {
+	ctx   AppContext
+	log   logrus.FieldLogger
+	lock  sync.Mutex
+	repos map[string]*managedRepository
+}
+
+func newRepositoryManager(ctx AppContext, log logrus.FieldLogger) *repositoryManager {
+	return &repositoryManager{
+		ctx:   ctx,
+		log:   log,
+		repos: map[string]*managedRepository{},
+	}
+}
+
+// add starts tracking a repository that is already known to the fetcher
+// and collector and starts its workers.
+func (m *repositoryManager) add(repo *github.Repository, discovered bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.addLocked(repo, discovered)
+}
+
+func (m *repositoryManager) addLocked(repo *github.Repository, discovered bool) {
+	managed := &managedRepository{
+		repo:       repo,
+		discovered: discovered,
+	}
+
+	m.repos[repo.FullName()] = managed
+	m.start(managed)
+}
+
+func (m *repositoryManager) start(managed *managedRepository) {
+	repoCtx, cancel := context.WithCancel(m.ctx.ctx)
+
+	appCtx := m.ctx
+	appCtx.ctx = repoCtx
+	managed.cancel = cancel
+
+	startRepository(appCtx, m.log.WithField("repo", managed.repo.FullName()), managed.repo)
+}
+
+// rediscover fetches the repositories of all owners, starts tracking new
+// ones and retires those that vanished.
+func (m *repositoryManager) rediscover() {
+	found := map[string]bool{}
+	failedOwners := map[string]bool{}
+
+	for _, owner := range m.ctx.options.owners {
+		repoNames, err := discoverRepositories(m.ctx.client, owner, m.ctx.filter)
+		if err != nil {
+			m.log.WithField("owner", owner).Warnf("Failed to discover repositories: %v", err)
+			failedOwners[owner] = true
+			continue
+		}
+
+		for _, repoName := range repoNames {
+			found[fmt.Sprintf("%s/%s", owner, repoName)] = true
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for fullName := range found {
+		managed, exists := m.repos[fullName]
+
+		switch {
+		case !exists:
+			parts := strings.SplitN(fullName, "/", 2)
+			repo := github.NewRepository(parts[0], parts[1])
+
+			if m.ctx.state != nil {
+				m.ctx.state.Restore(repo)
+			}
+
+			m.log.WithField("repo", fullName).Info("Discovered new repository.")
+			m.ctx.fetcher.AddRepository(repo)
+			m.ctx.collector.AddRepository(repo)
+			if m.ctx.apiServer != nil {
+				m.ctx.apiServer.AddRepository(repo)
+			}
+			m.addLocked(repo, true)
+
+		case managed.missingSince != nil:
+			m.log.WithField("repo", fullName).Info("Repository has reappeared.")
+			managed.missingSince = nil
+
+			if managed.cancel == nil {
+				m.ctx.fetcher.AddRepository(managed.repo)
+				m.start(managed)
+			}
+		}
+	}
+
+	now := time.Now()
+
+	for fullName, managed := range m.repos {
+		if !managed.discovered || found[fullName] || failedOwners[managed.repo.Owner] {
+			continue
+		}
+
+		// stop fetching data right away, but keep reporting the last
+		// known metrics until the grace period has passed
+		if managed.missingSince == nil {
+			m.log.WithField("repo", fullName).Info("Repository has vanished, retiring it.")
+
+			managed.missingSince = &now
+			managed.cancel()
+			managed.cancel = nil
+			m.ctx.fetcher.RemoveRepository(managed.repo)
+		}
+
+		if now.Sub(*managed.missingSince) >= m.ctx.options.ownerRetireGracePeriod {
+			m.log.WithField("repo", fullName).Info("Grace period has passed, removing repository metrics.")
+
+			m.ctx.collector.RemoveRepository(managed.repo)
+			if m.ctx.apiServer != nil {
+				m.ctx.apiServer.RemoveRepository(managed.repo)
+			}
+			delete(m.repos, fullName)
+		}
+	}
+}
+
+// repositories returns all currently tracked repositories, including
+// retired ones that are still within their grace period.
+func (m *repositoryManager) repositories() []*github.Repository {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	repos := []*github.Repository{}
+	for _, managed := range m.repos {
+		repos = append(repos, managed.repo)
+	}
+
+	return repos
+}
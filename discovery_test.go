@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"testing"
+
+	"go.xrstf.de/github_exporter/pkg/client"
+)
+
+func TestRepositoryFilterMatches(t *testing.T) {
+	testcases := []struct {
+		name     string
+		options  options
+		repo     client.OwnedRepository
+		expected bool
+	}{
+		{
+			name:     "plain repository",
+			repo:     client.OwnedRepository{Name: "foo"},
+			expected: true,
+		},
+		{
+			name:     "forks are excluded by default",
+			repo:     client.OwnedRepository{Name: "foo", IsFork: true},
+			expected: false,
+		},
+		{
+			name:     "forks can be included",
+			options:  options{ownerIncludeForks: true},
+			repo:     client.OwnedRepository{Name: "foo", IsFork: true},
+			expected: true,
+		},
+		{
+			name:     "archived repositories are excluded by default",
+			repo:     client.OwnedRepository{Name: "foo", IsArchived: true},
+			expected: false,
+		},
+		{
+			name:     "locked repositories are excluded by default",
+			repo:     client.OwnedRepository{Name: "foo", IsLocked: true},
+			expected: false,
+		},
+		{
+			name:     "private repositories can be excluded",
+			repo:     client.OwnedRepository{Name: "foo", IsPrivate: true},
+			expected: false,
+		},
+		{
+			name:     "include matches the full name",
+			options:  options{ownerInclude: "^acme/f"},
+			repo:     client.OwnedRepository{Name: "foo"},
+			expected: true,
+		},
+		{
+			name:     "include mismatch",
+			options:  options{ownerInclude: "^acme/bar$"},
+			repo:     client.OwnedRepository{Name: "foo"},
+			expected: false,
+		},
+		{
+			name:     "exclude wins over include",
+			options:  options{ownerInclude: "^acme/", ownerExclude: "foo"},
+			repo:     client.OwnedRepository{Name: "foo"},
+			expected: false,
+		},
+		{
+			name:     "topics are matched case-insensitively",
+			options:  options{ownerTopics: []string{"Kubernetes", "go"}},
+			repo:     client.OwnedRepository{Name: "foo", Topics: []string{"kubernetes"}},
+			expected: true,
+		},
+		{
+			name:     "repository without any wanted topic",
+			options:  options{ownerTopics: []string{"go"}},
+			repo:     client.OwnedRepository{Name: "foo", Topics: []string{"rust"}},
+			expected: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			filter, err := testcase.options.repositoryFilter()
+			if err != nil {
+				t.Fatalf("Failed to create filter: %v", err)
+			}
+
+			if matches := filter.Matches("acme", testcase.repo); matches != testcase.expected {
+				t.Fatalf("Expected Matches to return %v, got %v.", testcase.expected, matches)
+			}
+		})
+	}
+}
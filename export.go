@@ -0,0 +1,388 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/client"
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+)
+
+// runExport is a one-shot mode that fetches all pull requests, issues and
+// milestones of the given repositories, writes one file per item type and
+// exits.
+func runExport(args []string) {
+	opt := defaultOptions()
+
+	format := exportFormatCSV
+	outDir := "."
+
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s export:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+
+	fs.Var(&opt.repositories, "repo", "repository (owner/name format) to include, can be given multiple times")
+	fs.Var(&opt.owners, "owner", "github login (username or organization) of the owner of the repositories that will be included, can be given multiple times")
+	fs.BoolVar(&opt.ownerIncludeForks, "owner-include-forks", opt.ownerIncludeForks, "include forked repositories of the -owner")
+	fs.BoolVar(&opt.ownerIncludeLocked, "owner-include-locked", opt.ownerIncludeLocked, "include locked repositories of the -owner")
+	fs.BoolVar(&opt.ownerIncludeArchived, "owner-include-archived", opt.ownerIncludeArchived, "include archived repositories of the -owner")
+	fs.BoolVar(&opt.ownerIncludePrivate, "owner-include-private", opt.ownerIncludePrivate, "include private repositories of the -owner")
+	fs.StringVar(&opt.ownerInclude, "owner-include", opt.ownerInclude, "regular expression; only repositories of the -owner whose full name (owner/name) matches are included")
+	fs.StringVar(&opt.ownerExclude, "owner-exclude", opt.ownerExclude, "regular expression; repositories of the -owner whose full name (owner/name) matches are excluded")
+	fs.Var(&opt.ownerTopics, "owner-topic", "only include repositories of the -owner that have this topic, can be given multiple times")
+	fs.BoolVar(&opt.realnames, "realnames", opt.realnames, "use usernames instead of internal IDs for authors (this will make the export contain personally identifiable information)")
+	fs.BoolVar(&opt.pseudonymize, "pseudonymize", opt.pseudonymize, "use a keyed hash of the username instead of internal IDs for authors; the key is read from the PSEUDONYM_SECRET environment variable")
+	fs.Var(&opt.pseudonymAllow, "pseudonymize-allow", "username (e.g. of a bot account) to keep in clear text when using -pseudonymize, can be given multiple times")
+	fs.Var(&opt.botLogins, "bot-login", "username of a regular user account to treat as a bot, can be given multiple times")
+	fs.BoolVar(&opt.debugLog, "debug", opt.debugLog, "enable more verbose logging")
+	fs.StringVar(&format, "format", format, "output format (csv or ndjson)")
+	fs.StringVar(&outDir, "out", outDir, "directory to write the pulls, issues and milestones files to")
+
+	// ExitOnError takes care of errors
+	_ = fs.Parse(args)
+
+	log := newLogger(opt.debugLog)
+
+	if len(opt.owners) == 0 && len(opt.repositories) == 0 {
+		log.Fatal("No -repo nor -owner defined.")
+	}
+
+	if format != exportFormatCSV && format != exportFormatNDJSON {
+		log.Fatal("-format must be one of csv or ndjson.")
+	}
+
+	filter, err := opt.repositoryFilter()
+	if err != nil {
+		log.Fatalf("Invalid repository filter: %v", err)
+	}
+
+	identity, err := opt.identityOptions()
+	if err != nil {
+		log.Fatalf("Invalid identity options: %v", err)
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if len(token) == 0 {
+		log.Fatal("No GITHUB_TOKEN environment variable defined.")
+	}
+
+	c, err := client.NewClient(context.Background(), log.WithField("component", "client"), token, identity)
+	if err != nil {
+		log.Fatalf("Failed to create API client: %v", err)
+	}
+
+	repos := []repository{}
+	for _, owner := range opt.owners {
+		repoNames, err := discoverRepositories(c, owner, filter)
+		if err != nil {
+			log.Fatalf("Failed to discover repositories of %s: %v", owner, err)
+		}
+
+		for _, name := range repoNames {
+			repos = append(repos, repository{owner: owner, name: name})
+		}
+	}
+	repos = append(repos, opt.repositories...)
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	if err := export(c, log, repos, format, outDir); err != nil {
+		log.Fatalf("Export failed: %v", err)
+	}
+
+	log.Info("Export completed.")
+}
+
+func export(c *client.Client, log logrus.FieldLogger, repos []repository, format string, outDir string) error {
+	pulls, err := newExportWriter(filepath.Join(outDir, "pulls."+format), format, pullRequestColumns)
+	if err != nil {
+		return err
+	}
+	defer pulls.Close()
+
+	issues, err := newExportWriter(filepath.Join(outDir, "issues."+format), format, issueColumns)
+	if err != nil {
+		return err
+	}
+	defer issues.Close()
+
+	milestones, err := newExportWriter(filepath.Join(outDir, "milestones."+format), format, milestoneColumns)
+	if err != nil {
+		return err
+	}
+	defer milestones.Close()
+
+	for _, repo := range repos {
+		repoLog := log.WithField("repo", repo.String())
+		repoName := repo.String()
+
+		repoLog.Info("Exporting pull requests…")
+		cursor := ""
+		for {
+			var prs []github.PullRequest
+
+			prs, cursor, err = c.ListPullRequests(repo.owner, repo.name, nil, cursor)
+			if err != nil {
+				return fmt.Errorf("failed to list pull requests of %s: %w", repoName, err)
+			}
+
+			for _, pr := range prs {
+				if err := pulls.Write(pullRequestRecord(repoName, &pr)); err != nil {
+					return err
+				}
+			}
+
+			if cursor == "" {
+				break
+			}
+		}
+
+		repoLog.Info("Exporting issues…")
+		for {
+			var list []github.Issue
+
+			list, cursor, err = c.ListIssues(repo.owner, repo.name, nil, cursor)
+			if err != nil {
+				return fmt.Errorf("failed to list issues of %s: %w", repoName, err)
+			}
+
+			for _, issue := range list {
+				if err := issues.Write(issueRecord(repoName, &issue)); err != nil {
+					return err
+				}
+			}
+
+			if cursor == "" {
+				break
+			}
+		}
+
+		repoLog.Info("Exporting milestones…")
+		for {
+			var list []github.Milestone
+
+			list, cursor, err = c.ListMilestones(repo.owner, repo.name, nil, cursor)
+			if err != nil {
+				return fmt.Errorf("failed to list milestones of %s: %w", repoName, err)
+			}
+
+			for _, milestone := range list {
+				if err := milestones.Write(milestoneRecord(repoName, &milestone)); err != nil {
+					return err
+				}
+			}
+
+			if cursor == "" {
+				break
+			}
+		}
+	}
+
+	for _, w := range []*exportWriter{pulls, issues, milestones} {
+		if err := w.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var (
+	pullRequestColumns = []string{"repo", "number", "state", "author", "author_type", "author_association", "labels", "assignees", "milestone", "comments", "created_at", "updated_at", "closed_at", "merged_at", "merged_by"}
+	issueColumns       = []string{"repo", "number", "state", "state_reason", "author", "author_type", "author_association", "labels", "assignees", "milestone", "comments", "created_at", "updated_at", "closed_at"}
+	milestoneColumns   = []string{"repo", "number", "title", "state", "open_issues", "closed_issues", "open_pull_requests", "closed_pull_requests", "due_on", "created_at", "updated_at", "closed_at"}
+)
+
+// The record functions must return the values in the same order as the
+// corresponding columns.
+
+func pullRequestRecord(repo string, pr *github.PullRequest) []interface{} {
+	return []interface{}{
+		repo,
+		pr.Number,
+		strings.ToLower(string(pr.State)),
+		pr.Author,
+		string(pr.AuthorType),
+		strings.ToLower(string(pr.AuthorAssociation)),
+		pr.Labels,
+		pr.Assignees,
+		pr.Milestone,
+		pr.Comments,
+		pr.CreatedAt,
+		pr.UpdatedAt,
+		pr.ClosedAt,
+		pr.MergedAt,
+		pr.MergedBy,
+	}
+}
+
+func issueRecord(repo string, issue *github.Issue) []interface{} {
+	return []interface{}{
+		repo,
+		issue.Number,
+		strings.ToLower(string(issue.State)),
+		strings.ToLower(string(issue.StateReason)),
+		issue.Author,
+		string(issue.AuthorType),
+		strings.ToLower(string(issue.AuthorAssociation)),
+		issue.Labels,
+		issue.Assignees,
+		issue.Milestone,
+		issue.Comments,
+		issue.CreatedAt,
+		issue.UpdatedAt,
+		issue.ClosedAt,
+	}
+}
+
+func milestoneRecord(repo string, milestone *github.Milestone) []interface{} {
+	return []interface{}{
+		repo,
+		milestone.Number,
+		milestone.Title,
+		strings.ToLower(string(milestone.State)),
+		milestone.OpenIssues,
+		milestone.ClosedIssues,
+		milestone.OpenPullRequests,
+		milestone.ClosedPullRequests,
+		milestone.DueOn,
+		milestone.CreatedAt,
+		milestone.UpdatedAt,
+		milestone.ClosedAt,
+	}
+}
+
+// exportWriter writes records either as CSV (with a header line) or as
+// newline-delimited JSON objects.
+type exportWriter struct {
+	file    *os.File
+	buf     *bufio.Writer
+	csv     *csv.Writer
+	columns []string
+	closed  bool
+}
+
+func newExportWriter(filename string, format string, columns []string) (*exportWriter, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filename, err)
+	}
+
+	w := &exportWriter{
+		file:    f,
+		buf:     bufio.NewWriter(f),
+		columns: columns,
+	}
+
+	if format == exportFormatCSV {
+		w.csv = csv.NewWriter(w.buf)
+
+		if err := w.csv.Write(columns); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+func (w *exportWriter) Write(record []interface{}) error {
+	if len(record) != len(w.columns) {
+		return errors.New("record does not match the columns")
+	}
+
+	if w.csv != nil {
+		values := make([]string, len(record))
+		for i, value := range record {
+			values[i] = csvValue(value)
+		}
+
+		return w.csv.Write(values)
+	}
+
+	object := map[string]interface{}{}
+	for i, value := range record {
+		// encode nil timestamps as null instead of "0001-01-01T00:00:00Z"
+		if t, ok := value.(*time.Time); ok && t == nil {
+			value = nil
+		}
+
+		object[w.columns[i]] = value
+	}
+
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.buf.Write(encoded); err != nil {
+		return err
+	}
+
+	return w.buf.WriteByte('\n')
+}
+
+// Close flushes and closes the file; it is safe to call it multiple times.
+func (w *exportWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			w.file.Close()
+			return err
+		}
+	}
+
+	if err := w.buf.Flush(); err != nil {
+		w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
+
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ",")
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
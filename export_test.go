@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCSVValue(t *testing.T) {
+	timestamp := time.Date(2023, 6, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	testcases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{
+			name:     "string",
+			value:    "foo",
+			expected: "foo",
+		},
+		{
+			name:     "string list",
+			value:    []string{"kind/bug", "area/api"},
+			expected: "kind/bug,area/api",
+		},
+		{
+			name:     "empty string list",
+			value:    []string{},
+			expected: "",
+		},
+		{
+			name:     "integer",
+			value:    42,
+			expected: "42",
+		},
+		{
+			name:     "time is converted to UTC",
+			value:    timestamp,
+			expected: "2023-06-01T12:00:00Z",
+		},
+		{
+			name:     "time pointer",
+			value:    &timestamp,
+			expected: "2023-06-01T12:00:00Z",
+		},
+		{
+			name:     "nil time pointer",
+			value:    (*time.Time)(nil),
+			expected: "",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if value := csvValue(testcase.value); value != testcase.expected {
+				t.Fatalf("Expected %q, got %q.", testcase.expected, value)
+			}
+		})
+	}
+}
+
+func TestExportWriter(t *testing.T) {
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{"number", "labels", "created_at", "closed_at"}
+	record := []interface{}{7, []string{"a", "b"}, created, (*time.Time)(nil)}
+
+	testcases := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format:   exportFormatCSV,
+			expected: "number,labels,created_at,closed_at\n7,\"a,b\",2023-06-01T12:00:00Z,\n",
+		},
+		{
+			format:   exportFormatNDJSON,
+			expected: `{"closed_at":null,"created_at":"2023-06-01T12:00:00Z","labels":["a","b"],"number":7}` + "\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.format, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "export."+testcase.format)
+
+			w, err := newExportWriter(filename, testcase.format, columns)
+			if err != nil {
+				t.Fatalf("Failed to create writer: %v", err)
+			}
+
+			if err := w.Write(record); err != nil {
+				t.Fatalf("Failed to write record: %v", err)
+			}
+
+			if err := w.Write(record[1:]); err == nil {
+				t.Fatal("Expected an error when writing a record with the wrong number of values, but got none.")
+			}
+
+			if err := w.Close(); err != nil {
+				t.Fatalf("Failed to close writer: %v", err)
+			}
+
+			// closing twice must be safe
+			if err := w.Close(); err != nil {
+				t.Fatalf("Failed to close writer a second time: %v", err)
+			}
+
+			content, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("Failed to read export: %v", err)
+			}
+
+			if string(content) != testcase.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", testcase.expected, string(content))
+			}
+		})
+	}
+}
@@ -5,16 +5,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"time"
 
+	"go.xrstf.de/github_exporter/pkg/api"
 	"go.xrstf.de/github_exporter/pkg/client"
 	"go.xrstf.de/github_exporter/pkg/fetcher"
 	"go.xrstf.de/github_exporter/pkg/github"
 	"go.xrstf.de/github_exporter/pkg/metrics"
+	"go.xrstf.de/github_exporter/pkg/state"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -23,49 +26,133 @@ import (
 )
 
 type options struct {
-	repositories             repositoryList
-	owner                    string
-	realnames                bool
-	repoRefreshInterval      time.Duration
-	prRefreshInterval        time.Duration
-	prResyncInterval         time.Duration
-	prDepth                  int
-	issueRefreshInterval     time.Duration
-	issueResyncInterval      time.Duration
-	issueDepth               int
-	milestoneRefreshInterval time.Duration
-	milestoneResyncInterval  time.Duration
-	milestoneDepth           int
-	listenAddr               string
-	debugLog                 bool
+	repositories              repositoryList
+	owners                    stringList
+	ownerIncludeForks         bool
+	ownerIncludeLocked        bool
+	ownerIncludeArchived      bool
+	ownerIncludePrivate       bool
+	ownerInclude              string
+	ownerExclude              string
+	ownerTopics               stringList
+	ownerDiscoveryInterval    time.Duration
+	ownerRetireGracePeriod    time.Duration
+	realnames                 bool
+	pseudonymize              bool
+	pseudonymAllow            stringList
+	botLogins                 stringList
+	excludeBots               bool
+	repoRefreshInterval       time.Duration
+	trafficRefreshInterval    time.Duration
+	deploymentRefreshInterval time.Duration
+	deploymentWindow          time.Duration
+	orgRefreshInterval        time.Duration
+	prRefreshInterval         time.Duration
+	prResyncInterval          time.Duration
+	prDepth                   int
+	issueRefreshInterval      time.Duration
+	issueResyncInterval       time.Duration
+	issueDepth                int
+	milestoneRefreshInterval  time.Duration
+	milestoneResyncInterval   time.Duration
+	milestoneDepth            int
+	discussionRefreshInterval time.Duration
+	discussionResyncInterval  time.Duration
+	discussionDepth           int
+	projects                  projectList
+	projectStatusField        string
+	projectIterationField     string
+	projectRefreshInterval    time.Duration
+	awaitingResponseDays      intList
+	topReactedIssues          int
+	ageBuckets                durationList
+	itemSeries                string
+	itemSeriesWindow          time.Duration
+	timelineLabels            stringList
+	timelineRefreshInterval   time.Duration
+	configFile                string
+	stateFile                 string
+	stateSaveInterval         time.Duration
+	listenAddr                string
+	enableAPI                 bool
+	debugLog                  bool
 }
 
 type AppContext struct {
-	ctx     context.Context
-	client  *client.Client
-	fetcher *fetcher.Fetcher
-	options *options
+	ctx       context.Context
+	client    *client.Client
+	fetcher   *fetcher.Fetcher
+	collector *metrics.Collector
+	apiServer *api.Server
+	filter    *repositoryFilter
+	config    *config
+	state     *state.State
+	options   *options
+}
+
+// defaultOptions returns the defaults shared by the exporter and the export
+// subcommand, so that the same flags select the same repositories.
+func defaultOptions() options {
+	return options{
+		ownerIncludePrivate:       true,
+		ownerDiscoveryInterval:    1 * time.Hour,
+		ownerRetireGracePeriod:    1 * time.Hour,
+		repoRefreshInterval:       5 * time.Minute,
+		orgRefreshInterval:        1 * time.Hour,
+		deploymentWindow:          30 * 24 * time.Hour,
+		prRefreshInterval:         5 * time.Minute,
+		prResyncInterval:          12 * time.Hour,
+		prDepth:                   -1,
+		issueRefreshInterval:      5 * time.Minute,
+		issueResyncInterval:       12 * time.Hour,
+		issueDepth:                -1,
+		milestoneRefreshInterval:  5 * time.Minute,
+		milestoneResyncInterval:   12 * time.Hour,
+		milestoneDepth:            -1,
+		discussionRefreshInterval: 5 * time.Minute,
+		discussionResyncInterval:  12 * time.Hour,
+		discussionDepth:           -1,
+		projectStatusField:        "Status",
+		projectIterationField:     "Iteration",
+		projectRefreshInterval:    15 * time.Minute,
+		awaitingResponseDays:      intList{1, 7, 30},
+		topReactedIssues:          25,
+		ageBuckets:                durationList{24 * time.Hour, 3 * 24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour, 90 * 24 * time.Hour, 365 * 24 * time.Hour},
+		timelineRefreshInterval:   15 * time.Minute,
+		stateSaveInterval:         5 * time.Minute,
+		itemSeries:                string(metrics.ItemSeriesAll),
+		itemSeriesWindow:          30 * 24 * time.Hour,
+		listenAddr:                ":9612",
+	}
 }
 
 func main() {
-	opt := options{
-		repoRefreshInterval:      5 * time.Minute,
-		prRefreshInterval:        5 * time.Minute,
-		prResyncInterval:         12 * time.Hour,
-		prDepth:                  -1,
-		issueRefreshInterval:     5 * time.Minute,
-		issueResyncInterval:      12 * time.Hour,
-		issueDepth:               -1,
-		milestoneRefreshInterval: 5 * time.Minute,
-		milestoneResyncInterval:  12 * time.Hour,
-		milestoneDepth:           -1,
-		listenAddr:               ":9612",
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		runExport(os.Args[2:])
+		return
 	}
 
+	opt := defaultOptions()
+
 	flag.Var(&opt.repositories, "repo", "repository (owner/name format) to include, can be given multiple times")
-	flag.StringVar(&opt.owner, "owner", opt.owner, "github login (username or organization) of the owner of the repositories that will be included. Excludes forked and locked repo, includes 100 first private & public repos")
+	flag.Var(&opt.owners, "owner", "github login (username or organization) of the owner of the repositories that will be included, can be given multiple times")
+	flag.BoolVar(&opt.ownerIncludeForks, "owner-include-forks", opt.ownerIncludeForks, "include forked repositories of the -owner")
+	flag.BoolVar(&opt.ownerIncludeLocked, "owner-include-locked", opt.ownerIncludeLocked, "include locked repositories of the -owner")
+	flag.BoolVar(&opt.ownerIncludeArchived, "owner-include-archived", opt.ownerIncludeArchived, "include archived repositories of the -owner")
+	flag.BoolVar(&opt.ownerIncludePrivate, "owner-include-private", opt.ownerIncludePrivate, "include private repositories of the -owner")
+	flag.StringVar(&opt.ownerInclude, "owner-include", opt.ownerInclude, "regular expression; only repositories of the -owner whose full name (owner/name) matches are included")
+	flag.StringVar(&opt.ownerExclude, "owner-exclude", opt.ownerExclude, "regular expression; repositories of the -owner whose full name (owner/name) matches are excluded")
+	flag.Var(&opt.ownerTopics, "owner-topic", "only include repositories of the -owner that have this topic, can be given multiple times")
+	flag.DurationVar(&opt.ownerDiscoveryInterval, "owner-discovery-interval", opt.ownerDiscoveryInterval, "time in between re-discovering the repositories of the -owner (0 disables re-discovery)")
+	flag.DurationVar(&opt.ownerRetireGracePeriod, "owner-retire-grace-period", opt.ownerRetireGracePeriod, "time for which metrics of repositories that vanished from the -owner are still reported")
 	flag.BoolVar(&opt.realnames, "realnames", opt.realnames, "use usernames instead of internal IDs for author labels (this will make metrics contain personally identifiable information)")
+	flag.BoolVar(&opt.pseudonymize, "pseudonymize", opt.pseudonymize, "use a keyed hash of the username instead of internal IDs for author labels; the key is read from the PSEUDONYM_SECRET environment variable")
+	flag.Var(&opt.pseudonymAllow, "pseudonymize-allow", "username (e.g. of a bot account) to keep in clear text when using -pseudonymize, can be given multiple times")
 	flag.DurationVar(&opt.repoRefreshInterval, "repo-refresh-interval", opt.repoRefreshInterval, "time in between repository metadata refreshes")
+	flag.DurationVar(&opt.trafficRefreshInterval, "traffic-refresh-interval", opt.trafficRefreshInterval, "time in between repository traffic refreshes, requires push access (0 disables traffic metrics)")
+	flag.DurationVar(&opt.deploymentRefreshInterval, "deployment-refresh-interval", opt.deploymentRefreshInterval, "time in between deployment refreshes (0 disables deployment metrics)")
+	flag.DurationVar(&opt.deploymentWindow, "deployment-window", opt.deploymentWindow, "only deployments created within this time window are considered for deployment metrics")
+	flag.DurationVar(&opt.orgRefreshInterval, "org-refresh-interval", opt.orgRefreshInterval, "time in between organization metadata refreshes for the -owner (0 disables organization metrics)")
 	flag.IntVar(&opt.prDepth, "pr-depth", opt.prDepth, "max number of pull requests to fetch per repository upon startup (-1 disables the limit, 0 disables PR fetching entirely)")
 	flag.DurationVar(&opt.prRefreshInterval, "pr-refresh-interval", opt.prRefreshInterval, "time in between PR refreshes")
 	flag.DurationVar(&opt.prResyncInterval, "pr-resync-interval", opt.prResyncInterval, "time in between full PR re-syncs")
@@ -75,24 +162,36 @@ func main() {
 	flag.IntVar(&opt.milestoneDepth, "milestone-depth", opt.milestoneDepth, "max number of milestones to fetch per repository upon startup (-1 disables the limit, 0 disables milestone fetching entirely)")
 	flag.DurationVar(&opt.milestoneRefreshInterval, "milestone-refresh-interval", opt.milestoneRefreshInterval, "time in between milestone refreshes")
 	flag.DurationVar(&opt.milestoneResyncInterval, "milestone-resync-interval", opt.milestoneResyncInterval, "time in between full milestone re-syncs")
+	flag.IntVar(&opt.discussionDepth, "discussion-depth", opt.discussionDepth, "max number of discussions to fetch per repository upon startup (-1 disables the limit, 0 disables discussion fetching entirely)")
+	flag.DurationVar(&opt.discussionRefreshInterval, "discussion-refresh-interval", opt.discussionRefreshInterval, "time in between discussion refreshes")
+	flag.DurationVar(&opt.discussionResyncInterval, "discussion-resync-interval", opt.discussionResyncInterval, "time in between full discussion re-syncs")
+	flag.Var(&opt.projects, "project", "organization or user project (owner/number format) to include, can be given multiple times")
+	flag.StringVar(&opt.projectStatusField, "project-status-field", opt.projectStatusField, "name of the single-select project field that holds an item's status")
+	flag.StringVar(&opt.projectIterationField, "project-iteration-field", opt.projectIterationField, "name of the iteration project field")
+	flag.DurationVar(&opt.projectRefreshInterval, "project-refresh-interval", opt.projectRefreshInterval, "time in between project item refreshes")
+	flag.Var(&opt.awaitingResponseDays, "awaiting-response-days", "comma-separated list of thresholds (in days) for reporting open items without a human response")
+	flag.IntVar(&opt.topReactedIssues, "top-reacted-issues", opt.topReactedIssues, "number of open issues per repository with the most thumbs up reactions to report individual reaction metrics for (0 disables the metric)")
+	flag.Var(&opt.ageBuckets, "age-buckets", "comma-separated list of histogram buckets (durations) for the age of open issues and PRs (empty disables the histograms)")
+	flag.StringVar(&opt.itemSeries, "item-series", opt.itemSeries, "for which issues/PRs to report per-item series (all, open, or recent for open items and items closed within the -item-series-window)")
+	flag.DurationVar(&opt.itemSeriesWindow, "item-series-window", opt.itemSeriesWindow, "time window for -item-series=recent")
+	flag.Var(&opt.botLogins, "bot-login", "username of a regular user account to treat as a bot, can be given multiple times")
+	flag.BoolVar(&opt.excludeBots, "exclude-bots", opt.excludeBots, "exclude issues and PRs created by bots from aggregated metrics")
+	flag.Var(&opt.timelineLabels, "timeline-label", "label to report time-in-label metrics for, based on the issue/PR timelines, can be given multiple times")
+	flag.DurationVar(&opt.timelineRefreshInterval, "timeline-refresh-interval", opt.timelineRefreshInterval, "time in between fetching the timelines of changed issues and PRs (only used if -timeline-label is given)")
+	flag.StringVar(&opt.configFile, "config", opt.configFile, "optional JSON configuration file (e.g. for policies)")
+	flag.StringVar(&opt.stateFile, "state-file", opt.stateFile, "JSON file to persist data like milestone burndowns in across restarts (disabled if empty)")
+	flag.DurationVar(&opt.stateSaveInterval, "state-save-interval", opt.stateSaveInterval, "time in between saving the -state-file")
 	flag.StringVar(&opt.listenAddr, "listen", opt.listenAddr, "address and port to listen on")
+	flag.BoolVar(&opt.enableAPI, "api", opt.enableAPI, "serve the fetched repository data via a read-only JSON API under "+api.Prefix)
 	flag.BoolVar(&opt.debugLog, "debug", opt.debugLog, "enable more verbose logging")
 	flag.Parse()
 
 	// setup logging
-	var log = logrus.New()
-	log.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:   true,
-		TimestampFormat: time.RFC1123,
-	})
-
-	if opt.debugLog {
-		log.SetLevel(logrus.DebugLevel)
-	}
+	log := newLogger(opt.debugLog)
 
 	// validate CLI flags
-	if opt.owner == "" && len(opt.repositories) == 0 {
-		log.Fatal("No -repo nor -owner defined.")
+	if len(opt.owners) == 0 && len(opt.repositories) == 0 && len(opt.projects) == 0 {
+		log.Fatal("No -repo, -owner nor -project defined.")
 	}
 
 	if opt.prRefreshInterval >= opt.prResyncInterval {
@@ -107,6 +206,21 @@ func main() {
 		log.Fatal("-milestone-refresh-interval must be < than -milestone-resync-interval.")
 	}
 
+	if opt.discussionRefreshInterval >= opt.discussionResyncInterval {
+		log.Fatal("-discussion-refresh-interval must be < than -discussion-resync-interval.")
+	}
+
+	switch metrics.ItemSeriesMode(opt.itemSeries) {
+	case metrics.ItemSeriesAll, metrics.ItemSeriesOpen, metrics.ItemSeriesRecent:
+	default:
+		log.Fatal("-item-series must be one of all, open or recent.")
+	}
+
+	filter, err := opt.repositoryFilter()
+	if err != nil {
+		log.Fatalf("Invalid repository filter: %v", err)
+	}
+
 	token := os.Getenv("GITHUB_TOKEN")
 	if len(token) == 0 {
 		log.Fatal("No GITHUB_TOKEN environment variable defined.")
@@ -115,7 +229,12 @@ func main() {
 	// setup API client
 	ctx := context.Background()
 
-	client, err := client.NewClient(ctx, log.WithField("component", "client"), token, opt.realnames)
+	identity, err := opt.identityOptions()
+	if err != nil {
+		log.Fatalf("Invalid identity options: %v", err)
+	}
+
+	client, err := client.NewClient(ctx, log.WithField("component", "client"), token, identity)
 	if err != nil {
 		log.Fatalf("Failed to create API client: %v", err)
 	}
@@ -123,9 +242,30 @@ func main() {
 	appCtx := AppContext{
 		ctx:     ctx,
 		client:  client,
+		filter:  filter,
 		options: &opt,
 	}
 
+	appCtx.config = &config{}
+	if opt.configFile != "" {
+		appCtx.config, err = loadConfig(opt.configFile)
+		if err != nil {
+			log.Fatalf("Failed to load configuration file: %v", err)
+		}
+	}
+
+	if opt.stateFile != "" {
+		appCtx.state, err = state.Load(opt.stateFile)
+		if err != nil {
+			log.Fatalf("Failed to load state file: %v", err)
+		}
+	}
+
+	if opt.enableAPI {
+		appCtx.apiServer = api.NewServer(log.WithField("component", "api"))
+		http.Handle(api.Prefix, appCtx.apiServer)
+	}
+
 	// start fetching data in the background, but start metrics
 	// server as soon as possible
 	go setup(appCtx, log)
@@ -136,88 +276,298 @@ func main() {
 	log.Fatal(http.ListenAndServe(opt.listenAddr, nil))
 }
 
+func newLogger(debug bool) *logrus.Logger {
+	log := logrus.New()
+	log.SetFormatter(&logrus.TextFormatter{
+		FullTimestamp:   true,
+		TimestampFormat: time.RFC1123,
+	})
+
+	if debug {
+		log.SetLevel(logrus.DebugLevel)
+	}
+
+	return log
+}
+
+// identityOptions determines how users are identified, based on -realnames,
+// -pseudonymize and -bot-login.
+func (o *options) identityOptions() (client.IdentityOptions, error) {
+	identity := client.IdentityOptions{
+		Realnames:       o.realnames,
+		ClearTextLogins: o.pseudonymAllow,
+		BotLogins:       o.botLogins,
+	}
+
+	if o.pseudonymize {
+		if o.realnames {
+			return identity, errors.New("-pseudonymize and -realnames cannot be combined")
+		}
+
+		secret := os.Getenv("PSEUDONYM_SECRET")
+		if len(secret) == 0 {
+			return identity, errors.New("no PSEUDONYM_SECRET environment variable defined")
+		}
+
+		identity.PseudonymSecret = []byte(secret)
+	}
+
+	return identity, nil
+}
+
 func setup(ctx AppContext, log logrus.FieldLogger) {
 	repositories := map[string]*github.Repository{}
 
-	if ctx.options.owner != "" {
-		log.Infof("Fetching all repositories for %s", ctx.options.owner)
-		repoNames, err := ctx.client.RepositoriesNames(ctx.options.owner)
+	for _, owner := range ctx.options.owners {
+		log.Infof("Fetching all repositories for %s", owner)
+		repoNames, err := discoverRepositories(ctx.client, owner, ctx.filter)
 		if err != nil {
 			log.Fatalf("Failed to recover repositories: %v", err)
 		}
+		log.Infof("Found %d matching repositories for %s", len(repoNames), owner)
+
 		for _, repoName := range repoNames {
-			repositories[fmt.Sprintf("%s/%s", ctx.options.owner, repoName)] = github.NewRepository(ctx.options.owner, repoName)
+			repositories[fmt.Sprintf("%s/%s", owner, repoName)] = github.NewRepository(owner, repoName)
 		}
 	}
 
-	// create a PR database for each repo
+	// create a PR database for each repo; explicitly configured repositories
+	// are never retired, even if they vanish from their owner
+	staticRepositories := map[string]bool{}
 	for _, repo := range ctx.options.repositories {
 		repositories[repo.String()] = github.NewRepository(repo.owner, repo.name)
+		staticRepositories[repo.String()] = true
 	}
 
 	// setup the single-threaded fetcher
 	ctx.fetcher = fetcher.NewFetcher(ctx.client, repositories, log.WithField("component", "fetcher"))
+
+	projects := map[string]*github.Project{}
+	for _, p := range ctx.options.projects {
+		project := github.NewProject(p.owner, p.number, ctx.options.projectStatusField, ctx.options.projectIterationField)
+		projects[project.FullName()] = project
+
+		ctx.fetcher.AddProject(project)
+	}
+
+	orgs := map[string]*github.Organization{}
+	if ctx.options.orgRefreshInterval > 0 {
+		for _, owner := range ctx.options.owners {
+			// users have no organization data
+			isOrg, err := ctx.client.IsOrganization(owner)
+			if err != nil {
+				log.Fatalf("Failed to determine type of owner %s: %v", owner, err)
+			}
+
+			if !isOrg {
+				log.Infof("%s is not an organization, skipping organization metrics.", owner)
+				continue
+			}
+
+			org := github.NewOrganization(owner)
+			orgs[org.Login] = org
+
+			ctx.fetcher.AddOrganization(org)
+		}
+	}
+
 	go ctx.fetcher.Worker()
 
-	prometheus.MustRegister(metrics.NewCollector(repositories, ctx.fetcher, ctx.client))
+	collectorOpts := metrics.Options{
+		AwaitingResponseDays: ctx.options.awaitingResponseDays,
+		TopReactedIssues:     ctx.options.topReactedIssues,
+		TimelineLabels:       ctx.options.timelineLabels,
+		Policies:             ctx.config.Policies,
+		AgeBuckets:           ctx.options.ageBuckets.Seconds(),
+		ItemSeries:           metrics.ItemSeriesMode(ctx.options.itemSeries),
+		ItemSeriesWindow:     ctx.options.itemSeriesWindow,
+		ExcludeBots:          ctx.options.excludeBots,
+	}
+
+	ctx.collector = metrics.NewCollector(repositories, projects, orgs, ctx.fetcher, ctx.client, collectorOpts)
+	prometheus.MustRegister(ctx.collector)
 
 	// perform the initial scan sequentially across all repositories, otherwise
 	// it's likely that we trigger GitHub's anti abuse system
 	log.Info("Initializing repositories…")
 
-	hasLabelledMetrics := ctx.options.prDepth != 0 || ctx.options.issueDepth != 0 || ctx.options.milestoneDepth != 0
+	for identifier, project := range projects {
+		projectLog := log.WithField("project", identifier)
 
-	for identifier, repo := range repositories {
-		repoLog := log.WithField("repo", identifier)
+		projectLog.Info("Scheduling initial project scan…")
+		ctx.fetcher.EnqueueProjectScan(project)
 
-		repoLog.Info("Scheduling initial scans…")
-		ctx.fetcher.EnqueueRepoUpdate(repo)
+		go refreshProjectWorker(ctx, projectLog, project)
+	}
 
-		// keep repository metadata up-to-date
-		go refreshRepositoryInfoWorker(ctx, repoLog, repo)
+	for login, org := range orgs {
+		orgLog := log.WithField("org", login)
 
-		if hasLabelledMetrics {
-			ctx.fetcher.EnqueueLabelUpdate(repo)
-		}
+		orgLog.Info("Scheduling initial organization update…")
+		ctx.fetcher.EnqueueOrganizationUpdate(org)
 
-		if ctx.options.prDepth != 0 {
-			ctx.fetcher.EnqueuePullRequestScan(repo, ctx.options.prDepth)
+		go refreshOrganizationWorker(ctx, orgLog, org)
+	}
 
-			// keep refreshing open PRs
-			go refreshPullRequestsWorker(ctx, repoLog, repo)
+	manager := newRepositoryManager(ctx, log)
+	for identifier, repo := range repositories {
+		if ctx.state != nil {
+			ctx.state.Restore(repo)
+		}
 
-			// in a much larger interval, crawl all existing PRs to detect deletions and changes
-			// after a PR has been merged
-			go resyncPullRequestsWorker(ctx, repoLog, repo)
+		if ctx.apiServer != nil {
+			ctx.apiServer.AddRepository(repo)
 		}
 
-		if ctx.options.issueDepth != 0 {
-			ctx.fetcher.EnqueueIssueScan(repo, ctx.options.issueDepth)
+		manager.add(repo, !staticRepositories[identifier])
+	}
 
-			// keep refreshing open issues
-			go refreshIssuesWorker(ctx, repoLog, repo)
+	if ctx.state != nil {
+		go saveStateWorker(ctx, log, manager)
+	}
 
-			// in a much larger interval, crawl all existing issues to detect status changes
-			go resyncIssuesWorker(ctx, repoLog, repo)
-		}
+	if len(ctx.options.owners) > 0 && ctx.options.ownerDiscoveryInterval > 0 {
+		go rediscoverRepositoriesWorker(ctx, log, manager)
+	}
+}
 
-		if ctx.options.milestoneDepth != 0 {
-			ctx.fetcher.EnqueueMilestoneScan(repo, ctx.options.milestoneDepth)
+// startRepository schedules the initial scans for a repository and starts
+// its refresh workers, which run until ctx.ctx is cancelled.
+func startRepository(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
+	hasLabelledMetrics := ctx.options.prDepth != 0 || ctx.options.issueDepth != 0 || ctx.options.milestoneDepth != 0
 
-			// keep refreshing open milestones
-			go refreshMilestonesWorker(ctx, repoLog, repo)
+	log.Info("Scheduling initial scans…")
+	ctx.fetcher.EnqueueRepoUpdate(repo)
 
-			// in a much larger interval, crawl all existing milestones to detect status changes
-			go resyncMilestonesWorker(ctx, repoLog, repo)
-		}
+	// keep repository metadata up-to-date
+	go refreshRepositoryInfoWorker(ctx, log, repo)
+
+	if ctx.options.trafficRefreshInterval > 0 {
+		ctx.fetcher.EnqueueTrafficUpdate(repo)
+
+		go refreshTrafficWorker(ctx, log, repo)
+	}
+
+	// timelines are only fetched for already known issues and PRs, so
+	// there is no initial scan
+	if len(ctx.options.timelineLabels) > 0 && (ctx.options.prDepth != 0 || ctx.options.issueDepth != 0) {
+		go refreshTimelinesWorker(ctx, log, repo)
+	}
+
+	if ctx.options.deploymentRefreshInterval > 0 {
+		ctx.fetcher.EnqueueDeploymentScan(repo, ctx.options.deploymentWindow)
+
+		go refreshDeploymentsWorker(ctx, log, repo)
+	}
+
+	if hasLabelledMetrics {
+		ctx.fetcher.EnqueueLabelUpdate(repo)
+	}
+
+	if ctx.options.prDepth != 0 {
+		ctx.fetcher.EnqueuePullRequestScan(repo, ctx.options.prDepth)
+
+		// keep refreshing open PRs
+		go refreshPullRequestsWorker(ctx, log, repo)
+
+		// in a much larger interval, crawl all existing PRs to detect deletions and changes
+		// after a PR has been merged
+		go resyncPullRequestsWorker(ctx, log, repo)
+	}
+
+	if ctx.options.issueDepth != 0 {
+		ctx.fetcher.EnqueueIssueScan(repo, ctx.options.issueDepth)
+
+		// keep refreshing open issues
+		go refreshIssuesWorker(ctx, log, repo)
+
+		// in a much larger interval, crawl all existing issues to detect status changes
+		go resyncIssuesWorker(ctx, log, repo)
+	}
+
+	if ctx.options.milestoneDepth != 0 {
+		ctx.fetcher.EnqueueMilestoneScan(repo, ctx.options.milestoneDepth)
+
+		// keep refreshing open milestones
+		go refreshMilestonesWorker(ctx, log, repo)
+
+		// in a much larger interval, crawl all existing milestones to detect status changes
+		go resyncMilestonesWorker(ctx, log, repo)
+	}
+
+	if ctx.options.discussionDepth != 0 {
+		ctx.fetcher.EnqueueDiscussionScan(repo, ctx.options.discussionDepth)
+
+		// keep refreshing open discussions
+		go refreshDiscussionsWorker(ctx, log, repo)
+
+		// in a much larger interval, crawl all existing discussions to detect status changes
+		go resyncDiscussionsWorker(ctx, log, repo)
 	}
 }
 
+func saveStateWorker(ctx AppContext, log logrus.FieldLogger, manager *repositoryManager) {
+	every(ctx.ctx, ctx.options.stateSaveInterval, func() {
+		s := state.New()
+		for _, repo := range manager.repositories() {
+			s.Capture(repo)
+		}
+
+		if err := s.Save(ctx.options.stateFile); err != nil {
+			log.Warnf("Failed to save state: %v", err)
+		} else {
+			log.Debug("Saved state.")
+		}
+	})
+}
+
+func rediscoverRepositoriesWorker(ctx AppContext, log logrus.FieldLogger, manager *repositoryManager) {
+	every(ctx.ctx, ctx.options.ownerDiscoveryInterval, func() {
+		log.Debug("Re-discovering repositories…")
+		manager.rediscover()
+	})
+}
+
 func refreshRepositoryInfoWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
-	for range time.NewTicker(ctx.options.repoRefreshInterval).C {
+	every(ctx.ctx, ctx.options.repoRefreshInterval, func() {
 		log.Debug("Refreshing repository metadata…")
 		ctx.fetcher.EnqueueRepoUpdate(repo)
-	}
+	})
+}
+
+func refreshTrafficWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
+	every(ctx.ctx, ctx.options.trafficRefreshInterval, func() {
+		log.Debug("Refreshing repository traffic…")
+		ctx.fetcher.EnqueueTrafficUpdate(repo)
+	})
+}
+
+func refreshTimelinesWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
+	every(ctx.ctx, ctx.options.timelineRefreshInterval, func() {
+		log.Debug("Refreshing issue and pull request timelines…")
+		ctx.fetcher.EnqueueTimelineUpdate(repo)
+	})
+}
+
+func refreshDeploymentsWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
+	every(ctx.ctx, ctx.options.deploymentRefreshInterval, func() {
+		log.Debug("Refreshing deployments…")
+		ctx.fetcher.EnqueueDeploymentScan(repo, ctx.options.deploymentWindow)
+	})
+}
+
+func refreshOrganizationWorker(ctx AppContext, log logrus.FieldLogger, org *github.Organization) {
+	every(ctx.ctx, ctx.options.orgRefreshInterval, func() {
+		log.Debug("Refreshing organization metadata…")
+		ctx.fetcher.EnqueueOrganizationUpdate(org)
+	})
+}
+
+func refreshProjectWorker(ctx AppContext, log logrus.FieldLogger, project *github.Project) {
+	every(ctx.ctx, ctx.options.projectRefreshInterval, func() {
+		log.Debug("Refreshing project items…")
+		ctx.fetcher.EnqueueProjectScan(project)
+	})
 }
 
 // refreshRepositoriesWorker refreshes all OPEN pull requests, because changes
@@ -225,7 +575,7 @@ func refreshRepositoryInfoWorker(ctx AppContext, log logrus.FieldLogger, repo *g
 // want to closely track the mergability. It also fetches the last 50 updated
 // PRs to find cases where a PR was merged and is not open anymore.
 func refreshPullRequestsWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
-	for range time.NewTicker(ctx.options.prRefreshInterval).C {
+	every(ctx.ctx, ctx.options.prRefreshInterval, func() {
 		log.Debug("Refreshing open pull requests…")
 
 		numbers := []int{}
@@ -235,11 +585,11 @@ func refreshPullRequestsWorker(ctx AppContext, log logrus.FieldLogger, repo *git
 
 		ctx.fetcher.EnqueuePriorityPullRequests(repo, numbers)
 		ctx.fetcher.EnqueueUpdatedPullRequests(repo)
-	}
+	})
 }
 
 func resyncPullRequestsWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
-	for range time.NewTicker(ctx.options.prResyncInterval).C {
+	every(ctx.ctx, ctx.options.prResyncInterval, func() {
 		log.Info("Synchronizing repository pull requests…")
 
 		numbers := []int{}
@@ -249,11 +599,11 @@ func resyncPullRequestsWorker(ctx AppContext, log logrus.FieldLogger, repo *gith
 
 		ctx.fetcher.EnqueueRegularPullRequests(repo, numbers)
 		ctx.fetcher.EnqueueLabelUpdate(repo)
-	}
+	})
 }
 
 func refreshIssuesWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
-	for range time.NewTicker(ctx.options.issueRefreshInterval).C {
+	every(ctx.ctx, ctx.options.issueRefreshInterval, func() {
 		log.Debug("Refreshing open pull issues…")
 
 		numbers := []int{}
@@ -263,11 +613,11 @@ func refreshIssuesWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Re
 
 		ctx.fetcher.EnqueuePriorityIssues(repo, numbers)
 		ctx.fetcher.EnqueueUpdatedIssues(repo)
-	}
+	})
 }
 
 func resyncIssuesWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
-	for range time.NewTicker(ctx.options.issueResyncInterval).C {
+	every(ctx.ctx, ctx.options.issueResyncInterval, func() {
 		log.Info("Synchronizing repository issues…")
 
 		numbers := []int{}
@@ -277,11 +627,11 @@ func resyncIssuesWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Rep
 
 		ctx.fetcher.EnqueueRegularIssues(repo, numbers)
 		ctx.fetcher.EnqueueLabelUpdate(repo)
-	}
+	})
 }
 
 func refreshMilestonesWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
-	for range time.NewTicker(ctx.options.milestoneRefreshInterval).C {
+	every(ctx.ctx, ctx.options.milestoneRefreshInterval, func() {
 		log.Debug("Refreshing open pull milestones…")
 
 		numbers := []int{}
@@ -291,11 +641,11 @@ func refreshMilestonesWorker(ctx AppContext, log logrus.FieldLogger, repo *githu
 
 		ctx.fetcher.EnqueuePriorityMilestones(repo, numbers)
 		ctx.fetcher.EnqueueUpdatedMilestones(repo)
-	}
+	})
 }
 
 func resyncMilestonesWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
-	for range time.NewTicker(ctx.options.milestoneResyncInterval).C {
+	every(ctx.ctx, ctx.options.milestoneResyncInterval, func() {
 		log.Info("Synchronizing repository milestones…")
 
 		numbers := []int{}
@@ -305,5 +655,32 @@ func resyncMilestonesWorker(ctx AppContext, log logrus.FieldLogger, repo *github
 
 		ctx.fetcher.EnqueueRegularMilestones(repo, numbers)
 		ctx.fetcher.EnqueueLabelUpdate(repo)
-	}
+	})
+}
+
+func refreshDiscussionsWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
+	every(ctx.ctx, ctx.options.discussionRefreshInterval, func() {
+		log.Debug("Refreshing open discussions…")
+
+		numbers := []int{}
+		for _, discussion := range repo.GetDiscussions(githubv4.DiscussionStateOpen) {
+			numbers = append(numbers, discussion.Number)
+		}
+
+		ctx.fetcher.EnqueuePriorityDiscussions(repo, numbers)
+		ctx.fetcher.EnqueueUpdatedDiscussions(repo)
+	})
+}
+
+func resyncDiscussionsWorker(ctx AppContext, log logrus.FieldLogger, repo *github.Repository) {
+	every(ctx.ctx, ctx.options.discussionResyncInterval, func() {
+		log.Info("Synchronizing repository discussions…")
+
+		numbers := []int{}
+		for _, discussion := range repo.GetDiscussions(githubv4.DiscussionStateClosed) {
+			numbers = append(numbers, discussion.Number)
+		}
+
+		ctx.fetcher.EnqueueRegularDiscussions(repo, numbers)
+	})
 }
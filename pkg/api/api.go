@@ -0,0 +1,286 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package api implements a read-only JSON API for the repository data that
+// the exporter keeps in memory.
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Prefix is the path under which the API is served.
+	Prefix = "/api/v1/"
+
+	defaultPerPage = 30
+	maxPerPage     = 100
+)
+
+type Server struct {
+	lock  sync.RWMutex
+	repos map[string]*github.Repository
+	log   logrus.FieldLogger
+}
+
+func NewServer(log logrus.FieldLogger) *Server {
+	return &Server{
+		repos: map[string]*github.Repository{},
+		log:   log,
+	}
+}
+
+func (s *Server) AddRepository(repo *github.Repository) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.repos[strings.ToLower(repo.FullName())] = repo
+}
+
+func (s *Server) RemoveRepository(repo *github.Repository) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.repos, strings.ToLower(repo.FullName()))
+}
+
+func (s *Server) getRepository(owner string, name string) *github.Repository {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.repos[strings.ToLower(fmt.Sprintf("%s/%s", owner, name))]
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		s.sendError(w, http.StatusMethodNotAllowed, "only GET requests are supported")
+		return
+	}
+
+	// /api/v1/repos/{owner}/{name}/{kind}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/"), "/")
+	if parts[0] != "repos" {
+		s.sendError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if len(parts) == 1 {
+		s.listRepositories(w, r)
+		return
+	}
+
+	if len(parts) < 3 || len(parts) > 4 {
+		s.sendError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	repo := s.getRepository(parts[1], parts[2])
+	if repo == nil {
+		s.sendError(w, http.StatusNotFound, "repository not found")
+		return
+	}
+
+	if len(parts) == 3 {
+		var result repository
+
+		_ = repo.RLocked(func(repo *github.Repository) error {
+			result = convertRepository(repo)
+			return nil
+		})
+
+		s.sendJSON(w, http.StatusOK, result)
+		return
+	}
+
+	switch parts[3] {
+	case "pulls":
+		s.listPullRequests(w, r, repo)
+	case "issues":
+		s.listIssues(w, r, repo)
+	case "milestones":
+		s.listMilestones(w, r, repo)
+	default:
+		s.sendError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) listRepositories(w http.ResponseWriter, r *http.Request) {
+	s.lock.RLock()
+	repos := []*github.Repository{}
+	for _, repo := range s.repos {
+		repos = append(repos, repo)
+	}
+	s.lock.RUnlock()
+
+	result := []repository{}
+	for _, repo := range repos {
+		_ = repo.RLocked(func(repo *github.Repository) error {
+			result = append(result, convertRepository(repo))
+			return nil
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FullName < result[j].FullName
+	})
+
+	s.sendPage(w, r, len(result), func(start, end int) interface{} {
+		return result[start:end]
+	})
+}
+
+func (s *Server) listPullRequests(w http.ResponseWriter, r *http.Request, repo *github.Repository) {
+	filter, err := newItemFilter(r, "open", "closed", "merged")
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := []pullRequest{}
+	_ = repo.RLocked(func(repo *github.Repository) error {
+		for _, pr := range repo.PullRequests {
+			if filter.Matches(string(pr.State), pr.Author, pr.Assignees, pr.Milestone, pr.HasLabel) {
+				result = append(result, convertPullRequest(&pr))
+			}
+		}
+
+		return nil
+	})
+
+	// newest first, like on GitHub
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Number > result[j].Number
+	})
+
+	s.sendPage(w, r, len(result), func(start, end int) interface{} {
+		return result[start:end]
+	})
+}
+
+func (s *Server) listIssues(w http.ResponseWriter, r *http.Request, repo *github.Repository) {
+	filter, err := newItemFilter(r, "open", "closed")
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := []issue{}
+	_ = repo.RLocked(func(repo *github.Repository) error {
+		for _, i := range repo.Issues {
+			if filter.Matches(string(i.State), i.Author, i.Assignees, i.Milestone, i.HasLabel) {
+				result = append(result, convertIssue(&i))
+			}
+		}
+
+		return nil
+	})
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Number > result[j].Number
+	})
+
+	s.sendPage(w, r, len(result), func(start, end int) interface{} {
+		return result[start:end]
+	})
+}
+
+func (s *Server) listMilestones(w http.ResponseWriter, r *http.Request, repo *github.Repository) {
+	filter, err := newItemFilter(r, "open", "closed")
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := []milestone{}
+	_ = repo.RLocked(func(repo *github.Repository) error {
+		for _, m := range repo.Milestones {
+			if filter.MatchesState(string(m.State)) {
+				result = append(result, convertMilestone(&m))
+			}
+		}
+
+		return nil
+	})
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Number > result[j].Number
+	})
+
+	s.sendPage(w, r, len(result), func(start, end int) interface{} {
+		return result[start:end]
+	})
+}
+
+type page struct {
+	Total   int         `json:"total"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"perPage"`
+	Items   interface{} `json:"items"`
+}
+
+// sendPage sends the requested page (?page=N&per_page=M) of a list with
+// total elements; items must return the elements in [start, end).
+func (s *Server) sendPage(w http.ResponseWriter, r *http.Request, total int, items func(start, end int) interface{}) {
+	query := r.URL.Query()
+
+	pageNum, err := intParam(query.Get("page"), 1)
+	if err != nil || pageNum < 1 {
+		s.sendError(w, http.StatusBadRequest, "page must be a positive number")
+		return
+	}
+
+	perPage, err := intParam(query.Get("per_page"), defaultPerPage)
+	if err != nil || perPage < 1 || perPage > maxPerPage {
+		s.sendError(w, http.StatusBadRequest, fmt.Sprintf("per_page must be between 1 and %d", maxPerPage))
+		return
+	}
+
+	start := (pageNum - 1) * perPage
+	if start > total {
+		start = total
+	}
+
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	s.sendJSON(w, http.StatusOK, page{
+		Total:   total,
+		Page:    pageNum,
+		PerPage: perPage,
+		Items:   items(start, end),
+	})
+}
+
+func (s *Server) sendError(w http.ResponseWriter, status int, message string) {
+	s.sendJSON(w, status, map[string]string{"error": message})
+}
+
+func (s *Server) sendJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		s.log.Warnf("Failed to encode response: %v", err)
+	}
+}
+
+func intParam(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return strconv.Atoi(value)
+}
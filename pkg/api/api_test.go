@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestServerSendPage(t *testing.T) {
+	testcases := []struct {
+		name     string
+		query    string
+		total    int
+		status   int
+		page     int
+		perPage  int
+		expected string
+	}{
+		{
+			name:     "defaults",
+			query:    "",
+			total:    5,
+			status:   http.StatusOK,
+			page:     1,
+			perPage:  defaultPerPage,
+			expected: "[0 5]",
+		},
+		{
+			name:     "second page",
+			query:    "page=2&per_page=2",
+			total:    5,
+			status:   http.StatusOK,
+			page:     2,
+			perPage:  2,
+			expected: "[2 4]",
+		},
+		{
+			name:     "last page is partial",
+			query:    "page=3&per_page=2",
+			total:    5,
+			status:   http.StatusOK,
+			page:     3,
+			perPage:  2,
+			expected: "[4 5]",
+		},
+		{
+			name:     "page beyond the end is empty",
+			query:    "page=10&per_page=2",
+			total:    5,
+			status:   http.StatusOK,
+			page:     10,
+			perPage:  2,
+			expected: "[5 5]",
+		},
+		{
+			name:   "invalid page",
+			query:  "page=0",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "per_page too large",
+			query:  fmt.Sprintf("per_page=%d", maxPerPage+1),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "per_page not a number",
+			query:  "per_page=many",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	s := NewServer(logrus.New())
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/?"+testcase.query, nil)
+			w := httptest.NewRecorder()
+
+			s.sendPage(w, r, testcase.total, func(start, end int) interface{} {
+				return fmt.Sprintf("%v", []int{start, end})
+			})
+
+			if w.Code != testcase.status {
+				t.Fatalf("Expected status %d, got %d: %s", testcase.status, w.Code, w.Body.String())
+			}
+
+			if testcase.status != http.StatusOK {
+				return
+			}
+
+			result := page{}
+			if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+
+			if result.Total != testcase.total || result.Page != testcase.page || result.PerPage != testcase.perPage {
+				t.Fatalf("Expected total=%d, page=%d, perPage=%d, got %+v.", testcase.total, testcase.page, testcase.perPage, result)
+			}
+
+			if result.Items != testcase.expected {
+				t.Fatalf("Expected items %q, got %v.", testcase.expected, result.Items)
+			}
+		})
+	}
+}
+
+func TestServerMethods(t *testing.T) {
+	testcases := []struct {
+		method string
+		status int
+	}{
+		{
+			method: http.MethodGet,
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodHead,
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			method: http.MethodPost,
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+
+	s := NewServer(logrus.New())
+
+	for _, testcase := range testcases {
+		t.Run(testcase.method, func(t *testing.T) {
+			r := httptest.NewRequest(testcase.method, Prefix+"repos", nil)
+			w := httptest.NewRecorder()
+
+			s.ServeHTTP(w, r)
+
+			if w.Code != testcase.status {
+				t.Fatalf("Expected status %d, got %d: %s", testcase.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// itemFilter is built from the query string of list requests:
+//
+//	?state=open|closed|merged|all (default open)
+//	&label=... (can be given multiple times, all labels must be present)
+//	&author=...&assignee=...&milestone=N (0 for items without milestone)
+type itemFilter struct {
+	state     string
+	labels    []string
+	author    string
+	assignee  string
+	milestone *int
+}
+
+func newItemFilter(r *http.Request, validStates ...string) (*itemFilter, error) {
+	query := r.URL.Query()
+
+	filter := &itemFilter{
+		state:    strings.ToLower(query.Get("state")),
+		labels:   query["label"],
+		author:   query.Get("author"),
+		assignee: query.Get("assignee"),
+	}
+
+	switch filter.state {
+	case "":
+		filter.state = "open"
+	case "all":
+	default:
+		valid := false
+		for _, state := range validStates {
+			if filter.state == state {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return nil, fmt.Errorf("state must be one of all, %s", strings.Join(validStates, ", "))
+		}
+	}
+
+	if value := query.Get("milestone"); value != "" {
+		milestone, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("milestone must be a number")
+		}
+
+		filter.milestone = &milestone
+	}
+
+	return filter, nil
+}
+
+func (f *itemFilter) MatchesState(state string) bool {
+	return f.state == "all" || strings.EqualFold(f.state, state)
+}
+
+func (f *itemFilter) Matches(state string, author string, assignees []string, milestone int, hasLabel func(string) bool) bool {
+	if !f.MatchesState(state) {
+		return false
+	}
+
+	if f.author != "" && !strings.EqualFold(f.author, author) {
+		return false
+	}
+
+	if f.milestone != nil && *f.milestone != milestone {
+		return false
+	}
+
+	if f.assignee != "" {
+		assigned := false
+		for _, assignee := range assignees {
+			if strings.EqualFold(f.assignee, assignee) {
+				assigned = true
+				break
+			}
+		}
+
+		if !assigned {
+			return false
+		}
+	}
+
+	for _, label := range f.labels {
+		if !hasLabel(label) {
+			return false
+		}
+	}
+
+	return true
+}
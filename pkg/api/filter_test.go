@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewItemFilter(t *testing.T) {
+	testcases := []struct {
+		name    string
+		query   string
+		state   string
+		invalid bool
+	}{
+		{
+			name:  "defaults to open",
+			query: "",
+			state: "open",
+		},
+		{
+			name:  "all is always valid",
+			query: "state=all",
+			state: "all",
+		},
+		{
+			name:  "state is lowercased",
+			query: "state=MERGED",
+			state: "merged",
+		},
+		{
+			name:    "unknown state",
+			query:   "state=draft",
+			invalid: true,
+		},
+		{
+			name:    "invalid milestone",
+			query:   "milestone=v1",
+			invalid: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/?"+testcase.query, nil)
+
+			filter, err := newItemFilter(r, "open", "closed", "merged")
+			if testcase.invalid {
+				if err == nil {
+					t.Fatal("Expected an error, but got none.")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+
+			if filter.state != testcase.state {
+				t.Fatalf("Expected state %q, got %q.", testcase.state, filter.state)
+			}
+		})
+	}
+}
+
+func TestItemFilterMatches(t *testing.T) {
+	labels := []string{"kind/bug", "area/api"}
+	hasLabel := func(label string) bool {
+		for _, l := range labels {
+			if strings.EqualFold(l, label) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	testcases := []struct {
+		name     string
+		query    string
+		expected bool
+	}{
+		{
+			name:     "default filter",
+			query:    "",
+			expected: true,
+		},
+		{
+			name:     "state mismatch",
+			query:    "state=closed",
+			expected: false,
+		},
+		{
+			name:     "author is compared case-insensitively",
+			query:    "author=Alice",
+			expected: true,
+		},
+		{
+			name:     "author mismatch",
+			query:    "author=bob",
+			expected: false,
+		},
+		{
+			name:     "assignee",
+			query:    "assignee=carol",
+			expected: true,
+		},
+		{
+			name:     "assignee mismatch",
+			query:    "assignee=alice",
+			expected: false,
+		},
+		{
+			name:     "milestone",
+			query:    "milestone=3",
+			expected: true,
+		},
+		{
+			name:     "items without milestone",
+			query:    "milestone=0",
+			expected: false,
+		},
+		{
+			name:     "all labels present",
+			query:    "label=kind/bug&label=area/api",
+			expected: true,
+		},
+		{
+			name:     "label missing",
+			query:    "label=kind/bug&label=area/ui",
+			expected: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/?"+testcase.query, nil)
+
+			filter, err := newItemFilter(r, "open", "closed")
+			if err != nil {
+				t.Fatalf("Failed to create filter: %v", err)
+			}
+
+			if matches := filter.Matches("OPEN", "alice", []string{"dave", "carol"}, 3, hasLabel); matches != testcase.expected {
+				t.Fatalf("Expected Matches to return %v, got %v.", testcase.expected, matches)
+			}
+		})
+	}
+}
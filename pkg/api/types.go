@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/shurcooL/githubv4"
+)
+
+type repository struct {
+	Owner            string     `json:"owner"`
+	Name             string     `json:"name"`
+	FullName         string     `json:"fullName"`
+	DefaultBranch    string     `json:"defaultBranch,omitempty"`
+	Visibility       string     `json:"visibility,omitempty"`
+	Language         string     `json:"language,omitempty"`
+	Topics           []string   `json:"topics"`
+	IsArchived       bool       `json:"isArchived"`
+	IsFork           bool       `json:"isFork"`
+	Stargazers       int        `json:"stargazers"`
+	Forks            int        `json:"forks"`
+	OpenPullRequests int        `json:"openPullRequests"`
+	OpenIssues       int        `json:"openIssues"`
+	OpenMilestones   int        `json:"openMilestones"`
+	FetchedAt        *time.Time `json:"fetchedAt"`
+}
+
+func convertRepository(repo *github.Repository) repository {
+	r := repository{
+		Owner:         repo.Owner,
+		Name:          repo.Name,
+		FullName:      repo.FullName(),
+		DefaultBranch: repo.DefaultBranch,
+		Visibility:    repo.Visibility,
+		Language:      repo.Language,
+		Topics:        repo.Topics,
+		IsArchived:    repo.IsArchived,
+		IsFork:        repo.IsFork,
+		Stargazers:    repo.Stargazers,
+		Forks:         repo.Forks,
+		FetchedAt:     repo.FetchedAt,
+	}
+
+	for _, pr := range repo.PullRequests {
+		if pr.State == githubv4.PullRequestStateOpen {
+			r.OpenPullRequests++
+		}
+	}
+
+	for _, issue := range repo.Issues {
+		if issue.State == githubv4.IssueStateOpen {
+			r.OpenIssues++
+		}
+	}
+
+	for _, milestone := range repo.Milestones {
+		if milestone.State == githubv4.MilestoneStateOpen {
+			r.OpenMilestones++
+		}
+	}
+
+	return r
+}
+
+type pullRequest struct {
+	Number       int        `json:"number"`
+	State        string     `json:"state"`
+	Author       string     `json:"author"`
+	AuthorType   string     `json:"authorType"`
+	Labels       []string   `json:"labels"`
+	Assignees    []string   `json:"assignees"`
+	Milestone    int        `json:"milestone,omitempty"`
+	LinkedIssues []string   `json:"linkedIssues"`
+	Comments     int        `json:"comments"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	ClosedAt     *time.Time `json:"closedAt"`
+	MergedAt     *time.Time `json:"mergedAt"`
+	MergedBy     string     `json:"mergedBy,omitempty"`
+	FetchedAt    time.Time  `json:"fetchedAt"`
+}
+
+func convertPullRequest(pr *github.PullRequest) pullRequest {
+	linked := []string{}
+	for _, issue := range pr.LinkedIssues {
+		linked = append(linked, fmt.Sprintf("%s#%d", issue.Repository, issue.Number))
+	}
+
+	return pullRequest{
+		Number:       pr.Number,
+		State:        strings.ToLower(string(pr.State)),
+		Author:       pr.Author,
+		AuthorType:   string(pr.AuthorType),
+		Labels:       nonNil(pr.Labels),
+		Assignees:    nonNil(pr.Assignees),
+		Milestone:    pr.Milestone,
+		LinkedIssues: linked,
+		Comments:     pr.Comments,
+		CreatedAt:    pr.CreatedAt,
+		UpdatedAt:    pr.UpdatedAt,
+		ClosedAt:     pr.ClosedAt,
+		MergedAt:     pr.MergedAt,
+		MergedBy:     pr.MergedBy,
+		FetchedAt:    pr.FetchedAt,
+	}
+}
+
+type issue struct {
+	Number      int            `json:"number"`
+	State       string         `json:"state"`
+	StateReason string         `json:"stateReason,omitempty"`
+	Author      string         `json:"author"`
+	AuthorType  string         `json:"authorType"`
+	Labels      []string       `json:"labels"`
+	Assignees   []string       `json:"assignees"`
+	Milestone   int            `json:"milestone,omitempty"`
+	Comments    int            `json:"comments"`
+	Reactions   map[string]int `json:"reactions,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	ClosedAt    *time.Time     `json:"closedAt"`
+	FetchedAt   time.Time      `json:"fetchedAt"`
+}
+
+func convertIssue(i *github.Issue) issue {
+	return issue{
+		Number:      i.Number,
+		State:       strings.ToLower(string(i.State)),
+		StateReason: strings.ToLower(string(i.StateReason)),
+		Author:      i.Author,
+		AuthorType:  string(i.AuthorType),
+		Labels:      nonNil(i.Labels),
+		Assignees:   nonNil(i.Assignees),
+		Milestone:   i.Milestone,
+		Comments:    i.Comments,
+		Reactions:   i.Reactions,
+		CreatedAt:   i.CreatedAt,
+		UpdatedAt:   i.UpdatedAt,
+		ClosedAt:    i.ClosedAt,
+		FetchedAt:   i.FetchedAt,
+	}
+}
+
+type milestone struct {
+	Number             int        `json:"number"`
+	Title              string     `json:"title"`
+	State              string     `json:"state"`
+	OpenIssues         int        `json:"openIssues"`
+	ClosedIssues       int        `json:"closedIssues"`
+	OpenPullRequests   int        `json:"openPullRequests"`
+	ClosedPullRequests int        `json:"closedPullRequests"`
+	CompletionRatio    float64    `json:"completionRatio"`
+	DueOn              *time.Time `json:"dueOn"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+	ClosedAt           *time.Time `json:"closedAt"`
+	FetchedAt          time.Time  `json:"fetchedAt"`
+}
+
+func convertMilestone(m *github.Milestone) milestone {
+	return milestone{
+		Number:             m.Number,
+		Title:              m.Title,
+		State:              strings.ToLower(string(m.State)),
+		OpenIssues:         m.OpenIssues,
+		ClosedIssues:       m.ClosedIssues,
+		OpenPullRequests:   m.OpenPullRequests,
+		ClosedPullRequests: m.ClosedPullRequests,
+		CompletionRatio:    m.CompletionRatio(),
+		DueOn:              m.DueOn,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+		ClosedAt:           m.ClosedAt,
+		FetchedAt:          m.FetchedAt,
+	}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
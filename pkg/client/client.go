@@ -5,8 +5,16 @@ package client
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.xrstf.de/github_exporter/pkg/github"
 
 	"github.com/shurcooL/githubv4"
 	"github.com/sirupsen/logrus"
@@ -20,17 +28,57 @@ type rateLimit struct {
 
 var stopFetching = errors.New("stop fetching data pls")
 
+const (
+	// RequestKindRepository is used for requests for a single repository.
+	RequestKindRepository = "repository"
+	// RequestKindProject is used for requests for organization/user projects.
+	RequestKindProject = "project"
+	// RequestKindOwner is used for requests for an organization/user, like
+	// repository discovery or organization metadata.
+	RequestKindOwner = "owner"
+)
+
+// RequestTarget identifies what API requests were made for.
+type RequestTarget struct {
+	Kind string
+	Name string
+}
+
 type Client struct {
-	ctx             context.Context
-	client          *githubv4.Client
-	log             logrus.FieldLogger
-	realnames       bool
-	requests        map[string]int
-	remainingPoints int
-	totalCosts      map[string]int
+	ctx        context.Context
+	client     *githubv4.Client
+	httpClient *http.Client
+	log        logrus.FieldLogger
+	identity   IdentityOptions
+
+	// lock protects the request accounting below, as the client is used
+	// concurrently by the fetcher and the repository rediscovery.
+	lock                  sync.RWMutex
+	requests              map[RequestTarget]int
+	remainingPoints       int
+	remainingRESTRequests int
+	totalCosts            map[RequestTarget]int
+	labelFollowUps        map[string]map[int]struct{}
 }
 
-func NewClient(ctx context.Context, log logrus.FieldLogger, token string, realnames bool) (*Client, error) {
+// IdentityOptions control how users are identified in metrics.
+type IdentityOptions struct {
+	// Realnames uses logins instead of node IDs.
+	Realnames bool
+
+	// PseudonymSecret, if set, replaces users with a keyed HMAC of their
+	// login, which is stable but cannot be resolved back to the user.
+	PseudonymSecret []byte
+
+	// ClearTextLogins are not pseudonymized (e.g. bot accounts).
+	ClearTextLogins []string
+
+	// BotLogins are treated as bots, even if they are regular user
+	// accounts on GitHub.
+	BotLogins []string
+}
+
+func NewClient(ctx context.Context, log logrus.FieldLogger, token string, identity IdentityOptions) (*Client, error) {
 	if token == "" {
 		return nil, errors.New("token cannot be empty")
 	}
@@ -46,38 +94,108 @@ func NewClient(ctx context.Context, log logrus.FieldLogger, token string, realna
 	return &Client{
 		ctx:             ctx,
 		client:          client,
+		httpClient:      httpClient,
 		log:             log,
-		realnames:       realnames,
-		requests:        map[string]int{},
+		identity:        identity,
+		requests:        map[RequestTarget]int{},
 		remainingPoints: 0,
-		totalCosts:      map[string]int{},
+		totalCosts:      map[RequestTarget]int{},
+		labelFollowUps:  map[string]map[int]struct{}{},
 	}, nil
 }
 
 func (c *Client) GetRemainingPoints() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
 	return c.remainingPoints
 }
 
-func (c *Client) GetRequestCounts() map[string]int {
-	return c.requests
+func (c *Client) GetRemainingRESTRequests() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.remainingRESTRequests
+}
+
+// GetRequestCounts returns a copy of the number of requests per target.
+func (c *Client) GetRequestCounts() map[RequestTarget]int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return copyTargetCounts(c.requests)
+}
+
+// GetTotalCosts returns a copy of the spent points per target.
+func (c *Client) GetTotalCosts() map[RequestTarget]int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return copyTargetCounts(c.totalCosts)
 }
 
-func (c *Client) GetTotalCosts() map[string]int {
-	return c.totalCosts
+// GetLabelFollowUps returns the number of issues/PRs per repository whose
+// labels did not fit into a single page when they were last fetched.
+func (c *Client) GetLabelFollowUps() map[string]int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	result := make(map[string]int, len(c.labelFollowUps))
+	for key, numbers := range c.labelFollowUps {
+		result[key] = len(numbers)
+	}
+
+	return result
+}
+
+func copyTargetCounts(counts map[RequestTarget]int) map[RequestTarget]int {
+	result := make(map[RequestTarget]int, len(counts))
+	for key, count := range counts {
+		result[key] = count
+	}
+
+	return result
 }
 
 func (c *Client) countRequest(owner string, name string, rateLimit rateLimit) {
-	key := fmt.Sprintf("%s/%s", owner, name)
+	c.countRequestFor(RequestKindRepository, fmt.Sprintf("%s/%s", owner, name), rateLimit)
+}
+
+// countRequestFor records a request; this is used directly for requests
+// that do not target a single repository.
+func (c *Client) countRequestFor(kind string, name string, rateLimit rateLimit) {
+	key := RequestTarget{Kind: kind, Name: name}
 
-	val := c.requests[key]
-	c.requests[key] = val + 1
+	c.lock.Lock()
+	defer c.lock.Unlock()
 
-	val = c.totalCosts[key]
-	c.totalCosts[key] = val + rateLimit.Cost
+	c.requests[key]++
+	c.totalCosts[key] += rateLimit.Cost
 
 	c.remainingPoints = rateLimit.Remaining
 }
 
+// setLabelFollowUp records whether the labels of an issue/PR required
+// additional pages, so that every item is only counted once, no matter how
+// often it is fetched.
+func (c *Client) setLabelFollowUp(owner string, name string, number int, needed bool) {
+	key := fmt.Sprintf("%s/%s", owner, name)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !needed {
+		delete(c.labelFollowUps[key], number)
+		return
+	}
+
+	if _, ok := c.labelFollowUps[key]; !ok {
+		c.labelFollowUps[key] = map[int]struct{}{}
+	}
+
+	c.labelFollowUps[key][number] = struct{}{}
+}
+
 func getNumberedQueryVariables(numbers []int, max int) map[string]interface{} {
 	if len(numbers) > max {
 		panic(fmt.Sprintf("List contains more (%d) than possible (%d) PR numbers.", len(numbers), max))
@@ -100,3 +218,53 @@ func getNumberedQueryVariables(numbers []int, max int) map[string]interface{} {
 
 	return variables
 }
+
+// userIdentifier returns the value that should be used to identify a user
+// in metrics: either the login (if realnames are enabled), a pseudonym
+// (if a secret is configured) or the node ID.
+func (c *Client) userIdentifier(login string, id string) string {
+	if c.identity.Realnames {
+		return login
+	}
+
+	if len(c.identity.PseudonymSecret) > 0 {
+		return c.pseudonym(login)
+	}
+
+	return id
+}
+
+// authorType classifies a user as a bot if GitHub reports it as one or if
+// its login is configured as a bot login.
+func (c *Client) authorType(typename string, login string) github.AuthorType {
+	if typename == "Bot" {
+		return github.AuthorTypeBot
+	}
+
+	for _, bot := range c.identity.BotLogins {
+		if strings.EqualFold(login, bot) {
+			return github.AuthorTypeBot
+		}
+	}
+
+	return github.AuthorTypeUser
+}
+
+// pseudonym returns a keyed HMAC of the login. Logins on the allowlist are
+// returned as-is.
+func (c *Client) pseudonym(login string) string {
+	if login == "" {
+		return ""
+	}
+
+	for _, clearText := range c.identity.ClearTextLogins {
+		if strings.EqualFold(login, clearText) {
+			return login
+		}
+	}
+
+	mac := hmac.New(sha256.New, c.identity.PseudonymSecret)
+	mac.Write([]byte(strings.ToLower(login)))
+
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
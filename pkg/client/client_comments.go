@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// graphqlComments is embedded in issues and pull requests; only the most recent
+// comments are fetched, which is enough to determine if a human has responded.
+type graphqlComments struct {
+	TotalCount int
+	Nodes      []graphqlComment
+}
+
+type graphqlComment struct {
+	CreatedAt         time.Time
+	AuthorAssociation githubv4.CommentAuthorAssociation
+	Author            struct {
+		Typename string `graphql:"__typename"`
+		Login    string
+	}
+}
+
+// commentAuthorType classifies the author of a comment; accounts configured
+// as bot logins are treated like bots.
+func (c *Client) commentAuthorType(comment graphqlComment, itemAuthor string) github.CommentAuthorType {
+	switch {
+	case c.authorType(comment.Author.Typename, comment.Author.Login) == github.AuthorTypeBot:
+		return github.CommentAuthorTypeBot
+	case comment.Author.Login != "" && comment.Author.Login == itemAuthor:
+		return github.CommentAuthorTypeAuthor
+	}
+
+	switch comment.AuthorAssociation {
+	case githubv4.CommentAuthorAssociationMember, githubv4.CommentAuthorAssociationOwner, githubv4.CommentAuthorAssociationCollaborator:
+		return github.CommentAuthorTypeMember
+	default:
+		return github.CommentAuthorTypeOther
+	}
+}
+
+type commentSummary struct {
+	total                 int
+	lastCommentAt         *time.Time
+	lastCommentBy         github.CommentAuthorType
+	awaitingResponseSince *time.Time
+}
+
+// summarizeComments determines the last comment and whether the item is still
+// waiting for a human response. The comments must be sorted chronologically.
+func (c *Client) summarizeComments(comments graphqlComments, itemAuthor string, createdAt time.Time) commentSummary {
+	summary := commentSummary{
+		total: comments.TotalCount,
+	}
+
+	if len(comments.Nodes) > 0 {
+		last := comments.Nodes[len(comments.Nodes)-1]
+
+		summary.lastCommentAt = &last.CreatedAt
+		summary.lastCommentBy = c.commentAuthorType(last, itemAuthor)
+	}
+
+	// find the most recent comment by a human
+	for i := len(comments.Nodes) - 1; i >= 0; i-- {
+		comment := comments.Nodes[i]
+
+		switch c.commentAuthorType(comment, itemAuthor) {
+		case github.CommentAuthorTypeBot:
+			continue
+		case github.CommentAuthorTypeAuthor:
+			summary.awaitingResponseSince = &comment.CreatedAt
+		}
+
+		return summary
+	}
+
+	// if we fetched all comments and none of them was made by a human,
+	// the item is waiting since its creation; otherwise the relevant
+	// comments are too old to be known and we assume a response happened
+	if len(comments.Nodes) == comments.TotalCount {
+		summary.awaitingResponseSince = &createdAt
+	}
+
+	return summary
+}
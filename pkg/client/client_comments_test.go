@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/shurcooL/githubv4"
+)
+
+func TestClientSummarizeComments(t *testing.T) {
+	created := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time {
+		return created.Add(time.Duration(hours) * time.Hour)
+	}
+
+	comment := func(hours int, typename string, login string, association githubv4.CommentAuthorAssociation) graphqlComment {
+		c := graphqlComment{
+			CreatedAt:         at(hours),
+			AuthorAssociation: association,
+		}
+		c.Author.Typename = typename
+		c.Author.Login = login
+
+		return c
+	}
+
+	byAuthor := comment(1, "User", "alice", githubv4.CommentAuthorAssociationContributor)
+	byMember := comment(2, "User", "bob", githubv4.CommentAuthorAssociationMember)
+	byOther := comment(2, "User", "carol", githubv4.CommentAuthorAssociationNone)
+	byBot := comment(3, "Bot", "ci", githubv4.CommentAuthorAssociationNone)
+	byBotLogin := comment(3, "User", "Renovate", githubv4.CommentAuthorAssociationNone)
+
+	testcases := []struct {
+		name          string
+		comments      graphqlComments
+		lastBy        github.CommentAuthorType
+		awaitingSince *time.Time
+	}{
+		{
+			name:          "no comments",
+			comments:      graphqlComments{},
+			awaitingSince: &created,
+		},
+		{
+			name:          "only bot comments",
+			comments:      graphqlComments{TotalCount: 2, Nodes: []graphqlComment{byBot, byBotLogin}},
+			lastBy:        github.CommentAuthorTypeBot,
+			awaitingSince: &created,
+		},
+		{
+			name:          "bot comments after older, unknown comments",
+			comments:      graphqlComments{TotalCount: 5, Nodes: []graphqlComment{byBot}},
+			lastBy:        github.CommentAuthorTypeBot,
+			awaitingSince: nil,
+		},
+		{
+			name:          "author commented last",
+			comments:      graphqlComments{TotalCount: 2, Nodes: []graphqlComment{byMember, byAuthor}},
+			lastBy:        github.CommentAuthorTypeAuthor,
+			awaitingSince: &byAuthor.CreatedAt,
+		},
+		{
+			name:          "bot comments are skipped",
+			comments:      graphqlComments{TotalCount: 2, Nodes: []graphqlComment{byAuthor, byBot}},
+			lastBy:        github.CommentAuthorTypeBot,
+			awaitingSince: &byAuthor.CreatedAt,
+		},
+		{
+			name:          "configured bot logins are skipped",
+			comments:      graphqlComments{TotalCount: 2, Nodes: []graphqlComment{byAuthor, byBotLogin}},
+			lastBy:        github.CommentAuthorTypeBot,
+			awaitingSince: &byAuthor.CreatedAt,
+		},
+		{
+			name:          "member responded",
+			comments:      graphqlComments{TotalCount: 2, Nodes: []graphqlComment{byAuthor, byMember}},
+			lastBy:        github.CommentAuthorTypeMember,
+			awaitingSince: nil,
+		},
+		{
+			name:          "someone else responded",
+			comments:      graphqlComments{TotalCount: 2, Nodes: []graphqlComment{byAuthor, byOther, byBot}},
+			lastBy:        github.CommentAuthorTypeBot,
+			awaitingSince: nil,
+		},
+	}
+
+	c := &Client{identity: IdentityOptions{BotLogins: []string{"renovate"}}}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			summary := c.summarizeComments(testcase.comments, "alice", created)
+
+			if summary.total != testcase.comments.TotalCount {
+				t.Errorf("Expected %d total comments, got %d.", testcase.comments.TotalCount, summary.total)
+			}
+
+			if summary.lastCommentBy != testcase.lastBy {
+				t.Errorf("Expected last comment by %q, got %q.", testcase.lastBy, summary.lastCommentBy)
+			}
+
+			if len(testcase.comments.Nodes) == 0 && summary.lastCommentAt != nil {
+				t.Errorf("Expected no last comment time, got %v.", summary.lastCommentAt)
+			}
+
+			switch {
+			case testcase.awaitingSince == nil && summary.awaitingResponseSince != nil:
+				t.Errorf("Expected the item not to await a response, but it awaits one since %v.", summary.awaitingResponseSince)
+			case testcase.awaitingSince != nil && summary.awaitingResponseSince == nil:
+				t.Errorf("Expected the item to await a response since %v, but it does not.", testcase.awaitingSince)
+			case testcase.awaitingSince != nil && !summary.awaitingResponseSince.Equal(*testcase.awaitingSince):
+				t.Errorf("Expected the item to await a response since %v, got %v.", testcase.awaitingSince, summary.awaitingResponseSince)
+			}
+		})
+	}
+}
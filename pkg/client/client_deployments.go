@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+type graphqlDeployment struct {
+	ID          string
+	Environment string
+	State       githubv4.DeploymentState
+	CreatedAt   time.Time
+
+	Commit *struct {
+		CommittedDate time.Time
+	}
+
+	LatestStatus *struct {
+		State     githubv4.DeploymentStatusState
+		CreatedAt time.Time
+	}
+}
+
+func convertDeployment(api graphqlDeployment) github.Deployment {
+	deployment := github.Deployment{
+		ID:          api.ID,
+		Environment: api.Environment,
+		State:       api.State,
+		CreatedAt:   api.CreatedAt,
+	}
+
+	if api.Commit != nil {
+		committedAt := api.Commit.CommittedDate
+		deployment.CommittedAt = &committedAt
+	}
+
+	if api.LatestStatus != nil && api.LatestStatus.State == githubv4.DeploymentStatusStateSuccess {
+		succeededAt := api.LatestStatus.CreatedAt
+		deployment.SucceededAt = &succeededAt
+	}
+
+	return deployment
+}
+
+type listDeploymentsQuery struct {
+	RateLimit  rateLimit
+	Repository struct {
+		Deployments struct {
+			Nodes    []graphqlDeployment
+			PageInfo struct {
+				EndCursor   githubv4.String
+				HasNextPage bool
+			}
+		} `graphql:"deployments(first: 100, orderBy: {field: CREATED_AT, direction: DESC}, after: $cursor)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// ListDeployments returns a single page of deployments, newest first.
+func (c *Client) ListDeployments(owner string, name string, cursor string) ([]github.Deployment, string, error) {
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(name),
+	}
+
+	if cursor == "" {
+		variables["cursor"] = (*githubv4.String)(nil)
+	} else {
+		variables["cursor"] = githubv4.String(cursor)
+	}
+
+	var q listDeploymentsQuery
+
+	err := c.client.Query(c.ctx, &q, variables)
+	c.countRequest(owner, name, q.RateLimit)
+
+	c.log.WithFields(logrus.Fields{
+		"owner":  owner,
+		"name":   name,
+		"cursor": cursor,
+		"cost":   q.RateLimit.Cost,
+	}).Debugf("ListDeployments()")
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	deployments := []github.Deployment{}
+	for _, node := range q.Repository.Deployments.Nodes {
+		deployments = append(deployments, convertDeployment(node))
+	}
+
+	cursor = ""
+	if q.Repository.Deployments.PageInfo.HasNextPage {
+		cursor = string(q.Repository.Deployments.PageInfo.EndCursor)
+	}
+
+	return deployments, cursor, nil
+}
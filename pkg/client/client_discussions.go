@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"strings"
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+type graphqlDiscussion struct {
+	Number         int
+	Closed         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	AnswerChosenAt *time.Time
+	UpvoteCount    int
+
+	Author struct {
+		Login string
+		User  struct {
+			ID string
+		} `graphql:"... on User"`
+	}
+
+	Category struct {
+		Name string
+	}
+
+	Comments struct {
+		TotalCount int
+	}
+}
+
+func (c *Client) convertDiscussion(api graphqlDiscussion, fetchedAt time.Time) github.Discussion {
+	discussion := github.Discussion{
+		Number:      api.Number,
+		Author:      c.userIdentifier(api.Author.Login, api.Author.User.ID),
+		State:       githubv4.DiscussionStateOpen,
+		Category:    api.Category.Name,
+		IsAnswered:  api.AnswerChosenAt != nil,
+		UpvoteCount: api.UpvoteCount,
+		Comments:    api.Comments.TotalCount,
+		CreatedAt:   api.CreatedAt,
+		UpdatedAt:   api.UpdatedAt,
+		FetchedAt:   fetchedAt,
+	}
+
+	if api.Closed {
+		discussion.State = githubv4.DiscussionStateClosed
+	}
+
+	return discussion
+}
+
+func (c *Client) GetRepositoryDiscussions(owner string, name string, numbers []int) ([]github.Discussion, error) {
+	variables := getNumberedQueryVariables(numbers, MaxDiscussionsPerQuery)
+	variables["owner"] = githubv4.String(owner)
+	variables["name"] = githubv4.String(name)
+
+	var q numberedDiscussionQuery
+
+	err := c.client.Query(c.ctx, &q, variables)
+	c.countRequest(owner, name, q.RateLimit)
+
+	c.log.WithFields(logrus.Fields{
+		"owner":       owner,
+		"name":        name,
+		"discussions": len(numbers),
+		"cost":        q.RateLimit.Cost,
+	}).Debugf("GetRepositoryDiscussions()")
+
+	if err != nil && !strings.Contains(err.Error(), "Could not resolve to a Discussion") {
+		return nil, err
+	}
+
+	now := time.Now()
+	discussions := []github.Discussion{}
+	for _, discussion := range q.GetAll() {
+		discussions = append(discussions, c.convertDiscussion(discussion, now))
+	}
+
+	return discussions, nil
+}
+
+type listDiscussionsQuery struct {
+	RateLimit  rateLimit
+	Repository struct {
+		Discussions struct {
+			Nodes    []graphqlDiscussion
+			PageInfo struct {
+				EndCursor   githubv4.String
+				HasNextPage bool
+			}
+		} `graphql:"discussions(states: $states, first: 100, orderBy: {field: UPDATED_AT, direction: DESC}, after: $cursor)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+func (c *Client) ListDiscussions(owner string, name string, states []githubv4.DiscussionState, cursor string) ([]github.Discussion, string, error) {
+	if states == nil {
+		states = []githubv4.DiscussionState{
+			githubv4.DiscussionStateOpen,
+			githubv4.DiscussionStateClosed,
+		}
+	}
+
+	variables := map[string]interface{}{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(name),
+		"states": states,
+	}
+
+	if cursor == "" {
+		variables["cursor"] = (*githubv4.String)(nil)
+	} else {
+		variables["cursor"] = githubv4.String(cursor)
+	}
+
+	var q listDiscussionsQuery
+
+	err := c.client.Query(c.ctx, &q, variables)
+	c.countRequest(owner, name, q.RateLimit)
+
+	c.log.WithFields(logrus.Fields{
+		"owner":  owner,
+		"name":   name,
+		"cursor": cursor,
+		"cost":   q.RateLimit.Cost,
+	}).Debugf("ListDiscussions()")
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	discussions := []github.Discussion{}
+	for _, node := range q.Repository.Discussions.Nodes {
+		discussions = append(discussions, c.convertDiscussion(node, now))
+	}
+
+	cursor = ""
+	if q.Repository.Discussions.PageInfo.HasNextPage {
+		cursor = string(q.Repository.Discussions.PageInfo.EndCursor)
+	}
+
+	return discussions, cursor, nil
+}
@@ -0,0 +1,337 @@
+// This file has been generated by hack/generate-client.sh
+// Do not edit manually!
+
+package client
+
+import (
+	"fmt"
+)
+
+const (
+	MaxDiscussionsPerQuery = 100
+)
+
+type numberedDiscussionQuery struct {
+	RateLimit  rateLimit
+	Repository struct {
+		Discussion0  *graphqlDiscussion `graphql:"discussion0: discussion(number: $number0) @include(if: $has0)"`
+		Discussion1  *graphqlDiscussion `graphql:"discussion1: discussion(number: $number1) @include(if: $has1)"`
+		Discussion2  *graphqlDiscussion `graphql:"discussion2: discussion(number: $number2) @include(if: $has2)"`
+		Discussion3  *graphqlDiscussion `graphql:"discussion3: discussion(number: $number3) @include(if: $has3)"`
+		Discussion4  *graphqlDiscussion `graphql:"discussion4: discussion(number: $number4) @include(if: $has4)"`
+		Discussion5  *graphqlDiscussion `graphql:"discussion5: discussion(number: $number5) @include(if: $has5)"`
+		Discussion6  *graphqlDiscussion `graphql:"discussion6: discussion(number: $number6) @include(if: $has6)"`
+		Discussion7  *graphqlDiscussion `graphql:"discussion7: discussion(number: $number7) @include(if: $has7)"`
+		Discussion8  *graphqlDiscussion `graphql:"discussion8: discussion(number: $number8) @include(if: $has8)"`
+		Discussion9  *graphqlDiscussion `graphql:"discussion9: discussion(number: $number9) @include(if: $has9)"`
+		Discussion10 *graphqlDiscussion `graphql:"discussion10: discussion(number: $number10) @include(if: $has10)"`
+		Discussion11 *graphqlDiscussion `graphql:"discussion11: discussion(number: $number11) @include(if: $has11)"`
+		Discussion12 *graphqlDiscussion `graphql:"discussion12: discussion(number: $number12) @include(if: $has12)"`
+		Discussion13 *graphqlDiscussion `graphql:"discussion13: discussion(number: $number13) @include(if: $has13)"`
+		Discussion14 *graphqlDiscussion `graphql:"discussion14: discussion(number: $number14) @include(if: $has14)"`
+		Discussion15 *graphqlDiscussion `graphql:"discussion15: discussion(number: $number15) @include(if: $has15)"`
+		Discussion16 *graphqlDiscussion `graphql:"discussion16: discussion(number: $number16) @include(if: $has16)"`
+		Discussion17 *graphqlDiscussion `graphql:"discussion17: discussion(number: $number17) @include(if: $has17)"`
+		Discussion18 *graphqlDiscussion `graphql:"discussion18: discussion(number: $number18) @include(if: $has18)"`
+		Discussion19 *graphqlDiscussion `graphql:"discussion19: discussion(number: $number19) @include(if: $has19)"`
+		Discussion20 *graphqlDiscussion `graphql:"discussion20: discussion(number: $number20) @include(if: $has20)"`
+		Discussion21 *graphqlDiscussion `graphql:"discussion21: discussion(number: $number21) @include(if: $has21)"`
+		Discussion22 *graphqlDiscussion `graphql:"discussion22: discussion(number: $number22) @include(if: $has22)"`
+		Discussion23 *graphqlDiscussion `graphql:"discussion23: discussion(number: $number23) @include(if: $has23)"`
+		Discussion24 *graphqlDiscussion `graphql:"discussion24: discussion(number: $number24) @include(if: $has24)"`
+		Discussion25 *graphqlDiscussion `graphql:"discussion25: discussion(number: $number25) @include(if: $has25)"`
+		Discussion26 *graphqlDiscussion `graphql:"discussion26: discussion(number: $number26) @include(if: $has26)"`
+		Discussion27 *graphqlDiscussion `graphql:"discussion27: discussion(number: $number27) @include(if: $has27)"`
+		Discussion28 *graphqlDiscussion `graphql:"discussion28: discussion(number: $number28) @include(if: $has28)"`
+		Discussion29 *graphqlDiscussion `graphql:"discussion29: discussion(number: $number29) @include(if: $has29)"`
+		Discussion30 *graphqlDiscussion `graphql:"discussion30: discussion(number: $number30) @include(if: $has30)"`
+		Discussion31 *graphqlDiscussion `graphql:"discussion31: discussion(number: $number31) @include(if: $has31)"`
+		Discussion32 *graphqlDiscussion `graphql:"discussion32: discussion(number: $number32) @include(if: $has32)"`
+		Discussion33 *graphqlDiscussion `graphql:"discussion33: discussion(number: $number33) @include(if: $has33)"`
+		Discussion34 *graphqlDiscussion `graphql:"discussion34: discussion(number: $number34) @include(if: $has34)"`
+		Discussion35 *graphqlDiscussion `graphql:"discussion35: discussion(number: $number35) @include(if: $has35)"`
+		Discussion36 *graphqlDiscussion `graphql:"discussion36: discussion(number: $number36) @include(if: $has36)"`
+		Discussion37 *graphqlDiscussion `graphql:"discussion37: discussion(number: $number37) @include(if: $has37)"`
+		Discussion38 *graphqlDiscussion `graphql:"discussion38: discussion(number: $number38) @include(if: $has38)"`
+		Discussion39 *graphqlDiscussion `graphql:"discussion39: discussion(number: $number39) @include(if: $has39)"`
+		Discussion40 *graphqlDiscussion `graphql:"discussion40: discussion(number: $number40) @include(if: $has40)"`
+		Discussion41 *graphqlDiscussion `graphql:"discussion41: discussion(number: $number41) @include(if: $has41)"`
+		Discussion42 *graphqlDiscussion `graphql:"discussion42: discussion(number: $number42) @include(if: $has42)"`
+		Discussion43 *graphqlDiscussion `graphql:"discussion43: discussion(number: $number43) @include(if: $has43)"`
+		Discussion44 *graphqlDiscussion `graphql:"discussion44: discussion(number: $number44) @include(if: $has44)"`
+		Discussion45 *graphqlDiscussion `graphql:"discussion45: discussion(number: $number45) @include(if: $has45)"`
+		Discussion46 *graphqlDiscussion `graphql:"discussion46: discussion(number: $number46) @include(if: $has46)"`
+		Discussion47 *graphqlDiscussion `graphql:"discussion47: discussion(number: $number47) @include(if: $has47)"`
+		Discussion48 *graphqlDiscussion `graphql:"discussion48: discussion(number: $number48) @include(if: $has48)"`
+		Discussion49 *graphqlDiscussion `graphql:"discussion49: discussion(number: $number49) @include(if: $has49)"`
+		Discussion50 *graphqlDiscussion `graphql:"discussion50: discussion(number: $number50) @include(if: $has50)"`
+		Discussion51 *graphqlDiscussion `graphql:"discussion51: discussion(number: $number51) @include(if: $has51)"`
+		Discussion52 *graphqlDiscussion `graphql:"discussion52: discussion(number: $number52) @include(if: $has52)"`
+		Discussion53 *graphqlDiscussion `graphql:"discussion53: discussion(number: $number53) @include(if: $has53)"`
+		Discussion54 *graphqlDiscussion `graphql:"discussion54: discussion(number: $number54) @include(if: $has54)"`
+		Discussion55 *graphqlDiscussion `graphql:"discussion55: discussion(number: $number55) @include(if: $has55)"`
+		Discussion56 *graphqlDiscussion `graphql:"discussion56: discussion(number: $number56) @include(if: $has56)"`
+		Discussion57 *graphqlDiscussion `graphql:"discussion57: discussion(number: $number57) @include(if: $has57)"`
+		Discussion58 *graphqlDiscussion `graphql:"discussion58: discussion(number: $number58) @include(if: $has58)"`
+		Discussion59 *graphqlDiscussion `graphql:"discussion59: discussion(number: $number59) @include(if: $has59)"`
+		Discussion60 *graphqlDiscussion `graphql:"discussion60: discussion(number: $number60) @include(if: $has60)"`
+		Discussion61 *graphqlDiscussion `graphql:"discussion61: discussion(number: $number61) @include(if: $has61)"`
+		Discussion62 *graphqlDiscussion `graphql:"discussion62: discussion(number: $number62) @include(if: $has62)"`
+		Discussion63 *graphqlDiscussion `graphql:"discussion63: discussion(number: $number63) @include(if: $has63)"`
+		Discussion64 *graphqlDiscussion `graphql:"discussion64: discussion(number: $number64) @include(if: $has64)"`
+		Discussion65 *graphqlDiscussion `graphql:"discussion65: discussion(number: $number65) @include(if: $has65)"`
+		Discussion66 *graphqlDiscussion `graphql:"discussion66: discussion(number: $number66) @include(if: $has66)"`
+		Discussion67 *graphqlDiscussion `graphql:"discussion67: discussion(number: $number67) @include(if: $has67)"`
+		Discussion68 *graphqlDiscussion `graphql:"discussion68: discussion(number: $number68) @include(if: $has68)"`
+		Discussion69 *graphqlDiscussion `graphql:"discussion69: discussion(number: $number69) @include(if: $has69)"`
+		Discussion70 *graphqlDiscussion `graphql:"discussion70: discussion(number: $number70) @include(if: $has70)"`
+		Discussion71 *graphqlDiscussion `graphql:"discussion71: discussion(number: $number71) @include(if: $has71)"`
+		Discussion72 *graphqlDiscussion `graphql:"discussion72: discussion(number: $number72) @include(if: $has72)"`
+		Discussion73 *graphqlDiscussion `graphql:"discussion73: discussion(number: $number73) @include(if: $has73)"`
+		Discussion74 *graphqlDiscussion `graphql:"discussion74: discussion(number: $number74) @include(if: $has74)"`
+		Discussion75 *graphqlDiscussion `graphql:"discussion75: discussion(number: $number75) @include(if: $has75)"`
+		Discussion76 *graphqlDiscussion `graphql:"discussion76: discussion(number: $number76) @include(if: $has76)"`
+		Discussion77 *graphqlDiscussion `graphql:"discussion77: discussion(number: $number77) @include(if: $has77)"`
+		Discussion78 *graphqlDiscussion `graphql:"discussion78: discussion(number: $number78) @include(if: $has78)"`
+		Discussion79 *graphqlDiscussion `graphql:"discussion79: discussion(number: $number79) @include(if: $has79)"`
+		Discussion80 *graphqlDiscussion `graphql:"discussion80: discussion(number: $number80) @include(if: $has80)"`
+		Discussion81 *graphqlDiscussion `graphql:"discussion81: discussion(number: $number81) @include(if: $has81)"`
+		Discussion82 *graphqlDiscussion `graphql:"discussion82: discussion(number: $number82) @include(if: $has82)"`
+		Discussion83 *graphqlDiscussion `graphql:"discussion83: discussion(number: $number83) @include(if: $has83)"`
+		Discussion84 *graphqlDiscussion `graphql:"discussion84: discussion(number: $number84) @include(if: $has84)"`
+		Discussion85 *graphqlDiscussion `graphql:"discussion85: discussion(number: $number85) @include(if: $has85)"`
+		Discussion86 *graphqlDiscussion `graphql:"discussion86: discussion(number: $number86) @include(if: $has86)"`
+		Discussion87 *graphqlDiscussion `graphql:"discussion87: discussion(number: $number87) @include(if: $has87)"`
+		Discussion88 *graphqlDiscussion `graphql:"discussion88: discussion(number: $number88) @include(if: $has88)"`
+		Discussion89 *graphqlDiscussion `graphql:"discussion89: discussion(number: $number89) @include(if: $has89)"`
+		Discussion90 *graphqlDiscussion `graphql:"discussion90: discussion(number: $number90) @include(if: $has90)"`
+		Discussion91 *graphqlDiscussion `graphql:"discussion91: discussion(number: $number91) @include(if: $has91)"`
+		Discussion92 *graphqlDiscussion `graphql:"discussion92: discussion(number: $number92) @include(if: $has92)"`
+		Discussion93 *graphqlDiscussion `graphql:"discussion93: discussion(number: $number93) @include(if: $has93)"`
+		Discussion94 *graphqlDiscussion `graphql:"discussion94: discussion(number: $number94) @include(if: $has94)"`
+		Discussion95 *graphqlDiscussion `graphql:"discussion95: discussion(number: $number95) @include(if: $has95)"`
+		Discussion96 *graphqlDiscussion `graphql:"discussion96: discussion(number: $number96) @include(if: $has96)"`
+		Discussion97 *graphqlDiscussion `graphql:"discussion97: discussion(number: $number97) @include(if: $has97)"`
+		Discussion98 *graphqlDiscussion `graphql:"discussion98: discussion(number: $number98) @include(if: $has98)"`
+		Discussion99 *graphqlDiscussion `graphql:"discussion99: discussion(number: $number99) @include(if: $has99)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+func (r *numberedDiscussionQuery) GetAll() []graphqlDiscussion {
+	result := []graphqlDiscussion{}
+
+	for i := 0; i < MaxDiscussionsPerQuery; i++ {
+		if discussion := r.Get(i); discussion != nil {
+			result = append(result, *discussion)
+		}
+	}
+
+	return result
+}
+
+func (r *numberedDiscussionQuery) Get(index int) *graphqlDiscussion {
+	switch index {
+	case 0:
+		return r.Repository.Discussion0
+	case 1:
+		return r.Repository.Discussion1
+	case 2:
+		return r.Repository.Discussion2
+	case 3:
+		return r.Repository.Discussion3
+	case 4:
+		return r.Repository.Discussion4
+	case 5:
+		return r.Repository.Discussion5
+	case 6:
+		return r.Repository.Discussion6
+	case 7:
+		return r.Repository.Discussion7
+	case 8:
+		return r.Repository.Discussion8
+	case 9:
+		return r.Repository.Discussion9
+	case 10:
+		return r.Repository.Discussion10
+	case 11:
+		return r.Repository.Discussion11
+	case 12:
+		return r.Repository.Discussion12
+	case 13:
+		return r.Repository.Discussion13
+	case 14:
+		return r.Repository.Discussion14
+	case 15:
+		return r.Repository.Discussion15
+	case 16:
+		return r.Repository.Discussion16
+	case 17:
+		return r.Repository.Discussion17
+	case 18:
+		return r.Repository.Discussion18
+	case 19:
+		return r.Repository.Discussion19
+	case 20:
+		return r.Repository.Discussion20
+	case 21:
+		return r.Repository.Discussion21
+	case 22:
+		return r.Repository.Discussion22
+	case 23:
+		return r.Repository.Discussion23
+	case 24:
+		return r.Repository.Discussion24
+	case 25:
+		return r.Repository.Discussion25
+	case 26:
+		return r.Repository.Discussion26
+	case 27:
+		return r.Repository.Discussion27
+	case 28:
+		return r.Repository.Discussion28
+	case 29:
+		return r.Repository.Discussion29
+	case 30:
+		return r.Repository.Discussion30
+	case 31:
+		return r.Repository.Discussion31
+	case 32:
+		return r.Repository.Discussion32
+	case 33:
+		return r.Repository.Discussion33
+	case 34:
+		return r.Repository.Discussion34
+	case 35:
+		return r.Repository.Discussion35
+	case 36:
+		return r.Repository.Discussion36
+	case 37:
+		return r.Repository.Discussion37
+	case 38:
+		return r.Repository.Discussion38
+	case 39:
+		return r.Repository.Discussion39
+	case 40:
+		return r.Repository.Discussion40
+	case 41:
+		return r.Repository.Discussion41
+	case 42:
+		return r.Repository.Discussion42
+	case 43:
+		return r.Repository.Discussion43
+	case 44:
+		return r.Repository.Discussion44
+	case 45:
+		return r.Repository.Discussion45
+	case 46:
+		return r.Repository.Discussion46
+	case 47:
+		return r.Repository.Discussion47
+	case 48:
+		return r.Repository.Discussion48
+	case 49:
+		return r.Repository.Discussion49
+	case 50:
+		return r.Repository.Discussion50
+	case 51:
+		return r.Repository.Discussion51
+	case 52:
+		return r.Repository.Discussion52
+	case 53:
+		return r.Repository.Discussion53
+	case 54:
+		return r.Repository.Discussion54
+	case 55:
+		return r.Repository.Discussion55
+	case 56:
+		return r.Repository.Discussion56
+	case 57:
+		return r.Repository.Discussion57
+	case 58:
+		return r.Repository.Discussion58
+	case 59:
+		return r.Repository.Discussion59
+	case 60:
+		return r.Repository.Discussion60
+	case 61:
+		return r.Repository.Discussion61
+	case 62:
+		return r.Repository.Discussion62
+	case 63:
+		return r.Repository.Discussion63
+	case 64:
+		return r.Repository.Discussion64
+	case 65:
+		return r.Repository.Discussion65
+	case 66:
+		return r.Repository.Discussion66
+	case 67:
+		return r.Repository.Discussion67
+	case 68:
+		return r.Repository.Discussion68
+	case 69:
+		return r.Repository.Discussion69
+	case 70:
+		return r.Repository.Discussion70
+	case 71:
+		return r.Repository.Discussion71
+	case 72:
+		return r.Repository.Discussion72
+	case 73:
+		return r.Repository.Discussion73
+	case 74:
+		return r.Repository.Discussion74
+	case 75:
+		return r.Repository.Discussion75
+	case 76:
+		return r.Repository.Discussion76
+	case 77:
+		return r.Repository.Discussion77
+	case 78:
+		return r.Repository.Discussion78
+	case 79:
+		return r.Repository.Discussion79
+	case 80:
+		return r.Repository.Discussion80
+	case 81:
+		return r.Repository.Discussion81
+	case 82:
+		return r.Repository.Discussion82
+	case 83:
+		return r.Repository.Discussion83
+	case 84:
+		return r.Repository.Discussion84
+	case 85:
+		return r.Repository.Discussion85
+	case 86:
+		return r.Repository.Discussion86
+	case 87:
+		return r.Repository.Discussion87
+	case 88:
+		return r.Repository.Discussion88
+	case 89:
+		return r.Repository.Discussion89
+	case 90:
+		return r.Repository.Discussion90
+	case 91:
+		return r.Repository.Discussion91
+	case 92:
+		return r.Repository.Discussion92
+	case 93:
+		return r.Repository.Discussion93
+	case 94:
+		return r.Repository.Discussion94
+	case 95:
+		return r.Repository.Discussion95
+	case 96:
+		return r.Repository.Discussion96
+	case 97:
+		return r.Repository.Discussion97
+	case 98:
+		return r.Repository.Discussion98
+	case 99:
+		return r.Repository.Discussion99
+	}
+
+	panic(fmt.Sprintf("Index %d out of range [0,%d] when accessing discussion request", index, MaxDiscussionsPerQuery-1))
+}
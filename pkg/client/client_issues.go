@@ -14,42 +14,82 @@ import (
 )
 
 type graphqlIssue struct {
-	Number    int
-	State     githubv4.IssueState
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Number      int
+	State       githubv4.IssueState
+	StateReason githubv4.IssueStateReason
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ClosedAt    *time.Time
+
+	AuthorAssociation githubv4.CommentAuthorAssociation
 
 	Author struct {
-		Login string
-		User  struct {
+		Typename string `graphql:"__typename"`
+		Login    string
+		User     struct {
 			ID string
 		} `graphql:"... on User"`
 	}
 
-	Labels struct {
+	Labels graphqlLabels `graphql:"labels(first: 50)"`
+
+	Assignees struct {
 		Nodes []struct {
-			Name string
+			ID    string
+			Login string
+		}
+	} `graphql:"assignees(first: 10)"`
+
+	Milestone *struct {
+		Number int
+	}
+
+	Comments graphqlComments `graphql:"comments(last: 5)"`
+
+	ReactionGroups []struct {
+		Content  githubv4.ReactionContent
+		Reactors struct {
+			TotalCount int
 		}
-	} `graphql:"labels(first: 50)"`
+	}
 }
 
-func (c *Client) convertIssue(api graphqlIssue, fetchedAt time.Time) github.Issue {
+func (c *Client) convertIssue(owner string, name string, api graphqlIssue, fetchedAt time.Time) github.Issue {
 	issue := github.Issue{
-		Number:    api.Number,
-		Author:    api.Author.User.ID,
-		State:     api.State,
-		CreatedAt: api.CreatedAt,
-		UpdatedAt: api.UpdatedAt,
-		FetchedAt: fetchedAt,
-		Labels:    []string{},
+		Number:            api.Number,
+		Author:            c.userIdentifier(api.Author.Login, api.Author.User.ID),
+		AuthorType:        c.authorType(api.Author.Typename, api.Author.Login),
+		AuthorAssociation: api.AuthorAssociation,
+		State:             api.State,
+		StateReason:       api.StateReason,
+		CreatedAt:         api.CreatedAt,
+		UpdatedAt:         api.UpdatedAt,
+		ClosedAt:          api.ClosedAt,
+		FetchedAt:         fetchedAt,
+		Assignees:         []string{},
+		Reactions:         map[string]int{},
+	}
+
+	if api.Milestone != nil {
+		issue.Milestone = api.Milestone.Number
 	}
 
-	if c.realnames {
-		issue.Author = api.Author.Login
+	issue.Labels = c.itemLabels(owner, name, api.Number, api.Labels)
+
+	for _, group := range api.ReactionGroups {
+		if group.Reactors.TotalCount > 0 {
+			issue.Reactions[strings.ToLower(string(group.Content))] = group.Reactors.TotalCount
+		}
 	}
 
-	for _, label := range api.Labels.Nodes {
-		issue.Labels = append(issue.Labels, label.Name)
+	comments := c.summarizeComments(api.Comments, api.Author.Login, api.CreatedAt)
+	issue.Comments = comments.total
+	issue.LastCommentAt = comments.lastCommentAt
+	issue.LastCommentBy = comments.lastCommentBy
+	issue.AwaitingResponseSince = comments.awaitingResponseSince
+
+	for _, assignee := range api.Assignees.Nodes {
+		issue.Assignees = append(issue.Assignees, c.userIdentifier(assignee.Login, assignee.ID))
 	}
 
 	return issue
@@ -79,7 +119,7 @@ func (c *Client) GetRepositoryIssues(owner string, name string, numbers []int) (
 	now := time.Now()
 	issues := []github.Issue{}
 	for _, issue := range q.GetAll() {
-		issues = append(issues, c.convertIssue(issue, now))
+		issues = append(issues, c.convertIssue(owner, name, issue, now))
 	}
 
 	return issues, nil
@@ -137,7 +177,7 @@ func (c *Client) ListIssues(owner string, name string, states []githubv4.IssueSt
 	now := time.Now()
 	issues := []github.Issue{}
 	for _, node := range q.Repository.Issues.Nodes {
-		issues = append(issues, c.convertIssue(node, now))
+		issues = append(issues, c.convertIssue(owner, name, node, now))
 	}
 
 	cursor = ""
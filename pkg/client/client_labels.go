@@ -62,3 +62,88 @@ func (c *Client) RepositoryLabels(owner string, name string) ([]string, error) {
 
 	return labels, nil
 }
+
+type graphqlLabels struct {
+	Nodes []struct {
+		Name string
+	}
+	PageInfo struct {
+		EndCursor   githubv4.String
+		HasNextPage bool
+	}
+}
+
+type itemLabelsQuery struct {
+	RateLimit  rateLimit
+	Repository struct {
+		IssueOrPullRequest struct {
+			Issue struct {
+				Labels graphqlLabels `graphql:"labels(first: 100, after: $cursor)"`
+			} `graphql:"... on Issue"`
+			PullRequest struct {
+				Labels graphqlLabels `graphql:"labels(first: 100, after: $cursor)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"issueOrPullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// itemLabels returns the names of all labels of an issue or PR. If the first
+// page did not contain all labels, the remaining pages are fetched. On errors,
+// the labels fetched so far are returned.
+func (c *Client) itemLabels(owner string, name string, number int, labels graphqlLabels) []string {
+	result := []string{}
+	for _, label := range labels.Nodes {
+		result = append(result, label.Name)
+	}
+
+	c.setLabelFollowUp(owner, name, number, labels.PageInfo.HasNextPage)
+
+	if !labels.PageInfo.HasNextPage {
+		return result
+	}
+
+	variables := map[string]interface{}{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(name),
+		"number": githubv4.Int(number),
+		"cursor": githubv4.NewString(labels.PageInfo.EndCursor),
+	}
+
+	for {
+		var q itemLabelsQuery
+
+		err := c.client.Query(c.ctx, &q, variables)
+		c.countRequest(owner, name, q.RateLimit)
+
+		c.log.WithFields(logrus.Fields{
+			"owner":  owner,
+			"name":   name,
+			"number": number,
+			"cursor": variables["cursor"],
+			"cost":   q.RateLimit.Cost,
+		}).Debugf("itemLabels()")
+
+		if err != nil {
+			c.log.WithField("number", number).Warnf("Failed to fetch remaining labels: %v", err)
+			return result
+		}
+
+		// only one of the two is set, depending on the item type
+		page := q.Repository.IssueOrPullRequest.Issue.Labels
+		if len(page.Nodes) == 0 {
+			page = q.Repository.IssueOrPullRequest.PullRequest.Labels
+		}
+
+		for _, label := range page.Nodes {
+			result = append(result, label.Name)
+		}
+
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+
+		variables["cursor"] = githubv4.NewString(page.PageInfo.EndCursor)
+	}
+
+	return result
+}
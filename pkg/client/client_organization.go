@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+type organizationInfoQuery struct {
+	RateLimit    rateLimit
+	Organization struct {
+		MembersWithRole struct {
+			TotalCount int
+		}
+		PendingMembers struct {
+			TotalCount int
+		}
+		Teams struct {
+			Nodes []struct {
+				Slug    string
+				Members struct {
+					TotalCount int
+				} `graphql:"members(membership: IMMEDIATE)"`
+			}
+			PageInfo struct {
+				EndCursor   githubv4.String
+				HasNextPage bool
+			}
+		} `graphql:"teams(first: 100, after: $cursor)"`
+	} `graphql:"organization(login: $login)"`
+}
+
+type OrganizationInfo struct {
+	Members            int
+	PendingInvitations int
+	Teams              map[string]int
+}
+
+func (c *Client) OrganizationInfo(login string) (*OrganizationInfo, error) {
+	variables := map[string]interface{}{
+		"login":  githubv4.String(login),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var q organizationInfoQuery
+
+	info := &OrganizationInfo{
+		Teams: map[string]int{},
+	}
+
+	for {
+		err := c.client.Query(c.ctx, &q, variables)
+		c.countRequestFor(RequestKindOwner, login, q.RateLimit)
+
+		c.log.WithFields(logrus.Fields{
+			"login":  login,
+			"cursor": variables["cursor"],
+			"cost":   q.RateLimit.Cost,
+		}).Debugf("OrganizationInfo()")
+
+		if err != nil {
+			return nil, err
+		}
+
+		info.Members = q.Organization.MembersWithRole.TotalCount
+		info.PendingInvitations = q.Organization.PendingMembers.TotalCount
+
+		for _, team := range q.Organization.Teams.Nodes {
+			info.Teams[team.Slug] = team.Members.TotalCount
+		}
+
+		if !q.Organization.Teams.PageInfo.HasNextPage {
+			break
+		}
+
+		variables["cursor"] = githubv4.NewString(q.Organization.Teams.PageInfo.EndCursor)
+	}
+
+	return info, nil
+}
+
+type repositoryOutsideCollaboratorsQuery struct {
+	RateLimit  rateLimit
+	Repository struct {
+		Collaborators struct {
+			Nodes []struct {
+				Login string
+			}
+			PageInfo struct {
+				EndCursor   githubv4.String
+				HasNextPage bool
+			}
+		} `graphql:"collaborators(affiliation: OUTSIDE, first: 100, after: $cursor)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// OrganizationOutsideCollaborators returns the number of distinct outside
+// collaborators across all repositories of an organization. Listing the
+// collaborators of a repository requires push/admin permissions, so
+// repositories without sufficient permissions are skipped; their number
+// is returned as well.
+func (c *Client) OrganizationOutsideCollaborators(login string) (int, int, error) {
+	repos, err := c.OwnedRepositories(login)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	collaborators := map[string]struct{}{}
+	skipped := 0
+
+	for _, repo := range repos {
+		logins, err := c.repositoryOutsideCollaborators(login, repo.Name)
+		if err != nil {
+			if !isPermissionError(err) {
+				return 0, skipped, err
+			}
+
+			c.log.WithField("repo", fmt.Sprintf("%s/%s", login, repo.Name)).Debugf("Skipping repository for outside collaborators: %v", err)
+			skipped++
+			continue
+		}
+
+		for _, collaborator := range logins {
+			collaborators[collaborator] = struct{}{}
+		}
+	}
+
+	return len(collaborators), skipped, nil
+}
+
+func (c *Client) repositoryOutsideCollaborators(owner string, name string) ([]string, error) {
+	variables := map[string]interface{}{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(name),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var q repositoryOutsideCollaboratorsQuery
+
+	logins := []string{}
+
+	for {
+		err := c.client.Query(c.ctx, &q, variables)
+		c.countRequestFor(RequestKindOwner, owner, q.RateLimit)
+
+		c.log.WithFields(logrus.Fields{
+			"owner":  owner,
+			"name":   name,
+			"cursor": variables["cursor"],
+			"cost":   q.RateLimit.Cost,
+		}).Debugf("repositoryOutsideCollaborators()")
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, collaborator := range q.Repository.Collaborators.Nodes {
+			logins = append(logins, collaborator.Login)
+		}
+
+		if !q.Repository.Collaborators.PageInfo.HasNextPage {
+			break
+		}
+
+		variables["cursor"] = githubv4.NewString(q.Repository.Collaborators.PageInfo.EndCursor)
+	}
+
+	return logins, nil
+}
+
+// isPermissionError returns true if GitHub refused to return data because
+// the token lacks the required permissions. The GraphQL client does not
+// expose the error type, so the message has to be checked.
+func isPermissionError(err error) bool {
+	message := strings.ToLower(err.Error())
+
+	for _, hint := range []string{"must have push access", "must have admin", "forbidden", "resource not accessible"} {
+		if strings.Contains(message, hint) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type repositoryOwnerTypeQuery struct {
+	RateLimit       rateLimit
+	RepositoryOwner struct {
+		Typename string `graphql:"__typename"`
+	} `graphql:"repositoryOwner(login: $login)"`
+}
+
+// IsOrganization returns true if the login belongs to an organization
+// rather than a user.
+func (c *Client) IsOrganization(login string) (bool, error) {
+	variables := map[string]interface{}{
+		"login": githubv4.String(login),
+	}
+
+	var q repositoryOwnerTypeQuery
+
+	err := c.client.Query(c.ctx, &q, variables)
+	c.countRequestFor(RequestKindOwner, login, q.RateLimit)
+
+	c.log.WithFields(logrus.Fields{
+		"login": login,
+		"cost":  q.RateLimit.Cost,
+	}).Debugf("IsOrganization()")
+
+	if err != nil {
+		return false, err
+	}
+
+	return q.RepositoryOwner.Typename == "Organization", nil
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+type graphqlProjectContent struct {
+	Number     int
+	Repository struct {
+		NameWithOwner string
+	}
+}
+
+type graphqlProjectItem struct {
+	Type    githubv4.ProjectV2ItemType
+	Content struct {
+		Issue       graphqlProjectContent `graphql:"... on Issue"`
+		PullRequest graphqlProjectContent `graphql:"... on PullRequest"`
+	}
+
+	Status struct {
+		SingleSelectValue struct {
+			Name string
+		} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	} `graphql:"status: fieldValueByName(name: $statusField)"`
+
+	Iteration struct {
+		IterationValue struct {
+			Title string
+		} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+	} `graphql:"iteration: fieldValueByName(name: $iterationField)"`
+}
+
+func convertProjectItem(api graphqlProjectItem) github.ProjectItem {
+	item := github.ProjectItem{
+		Type:      api.Type,
+		Status:    api.Status.SingleSelectValue.Name,
+		Iteration: api.Iteration.IterationValue.Title,
+	}
+
+	switch api.Type {
+	case githubv4.ProjectV2ItemTypeIssue:
+		item.Repository = api.Content.Issue.Repository.NameWithOwner
+		item.Number = api.Content.Issue.Number
+	case githubv4.ProjectV2ItemTypePullRequest:
+		item.Repository = api.Content.PullRequest.Repository.NameWithOwner
+		item.Number = api.Content.PullRequest.Number
+	}
+
+	return item
+}
+
+type listProjectItemsQuery struct {
+	RateLimit       rateLimit
+	RepositoryOwner struct {
+		ProjectOwner struct {
+			ProjectV2 struct {
+				Title string
+				Items struct {
+					Nodes    []graphqlProjectItem
+					PageInfo struct {
+						EndCursor   githubv4.String
+						HasNextPage bool
+					}
+				} `graphql:"items(first: 100, after: $cursor)"`
+			} `graphql:"projectV2(number: $number)"`
+		} `graphql:"... on ProjectV2Owner"`
+	} `graphql:"repositoryOwner(login: $login)"`
+}
+
+// ListProjectItems returns a single page of items of an organization's or
+// user's project, together with the project's title.
+func (c *Client) ListProjectItems(project *github.Project, cursor string) ([]github.ProjectItem, string, string, error) {
+	variables := map[string]interface{}{
+		"login":          githubv4.String(project.Owner),
+		"number":         githubv4.Int(project.Number),
+		"statusField":    githubv4.String(project.StatusField),
+		"iterationField": githubv4.String(project.IterationField),
+	}
+
+	if cursor == "" {
+		variables["cursor"] = (*githubv4.String)(nil)
+	} else {
+		variables["cursor"] = githubv4.String(cursor)
+	}
+
+	var q listProjectItemsQuery
+
+	err := c.client.Query(c.ctx, &q, variables)
+	c.countRequestFor(RequestKindProject, project.FullName(), q.RateLimit)
+
+	c.log.WithFields(logrus.Fields{
+		"owner":  project.Owner,
+		"number": project.Number,
+		"cursor": cursor,
+		"cost":   q.RateLimit.Cost,
+	}).Debugf("ListProjectItems()")
+
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	projectV2 := q.RepositoryOwner.ProjectOwner.ProjectV2
+
+	items := []github.ProjectItem{}
+	for _, node := range projectV2.Items.Nodes {
+		items = append(items, convertProjectItem(node))
+	}
+
+	cursor = ""
+	if projectV2.Items.PageInfo.HasNextPage {
+		cursor = string(projectV2.Items.PageInfo.EndCursor)
+	}
+
+	return items, projectV2.Title, cursor, nil
+}
@@ -18,19 +18,49 @@ type graphqlPullRequest struct {
 	State     githubv4.PullRequestState
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	ClosedAt  *time.Time
+	MergedAt  *time.Time
+
+	AuthorAssociation githubv4.CommentAuthorAssociation
 
 	Author struct {
+		Typename string `graphql:"__typename"`
+		Login    string
+		User     struct {
+			ID string
+		} `graphql:"... on User"`
+	}
+
+	MergedBy *struct {
 		Login string
 		User  struct {
 			ID string
 		} `graphql:"... on User"`
 	}
 
-	Labels struct {
+	Labels graphqlLabels `graphql:"labels(first: 50)"`
+
+	Assignees struct {
 		Nodes []struct {
-			Name string
+			ID    string
+			Login string
 		}
-	} `graphql:"labels(first: 50)"`
+	} `graphql:"assignees(first: 10)"`
+
+	Milestone *struct {
+		Number int
+	}
+
+	Comments graphqlComments `graphql:"comments(last: 5)"`
+
+	ClosingIssuesReferences struct {
+		Nodes []struct {
+			Number     int
+			Repository struct {
+				NameWithOwner string
+			}
+		}
+	} `graphql:"closingIssuesReferences(first: 10)"`
 
 	Commits struct {
 		Nodes []struct {
@@ -46,24 +76,48 @@ type graphqlPullRequest struct {
 	} `graphql:"commits(last: 1)"`
 }
 
-func (c *Client) convertPullRequest(api graphqlPullRequest, fetchedAt time.Time) github.PullRequest {
+func (c *Client) convertPullRequest(owner string, name string, api graphqlPullRequest, fetchedAt time.Time) github.PullRequest {
 	pr := github.PullRequest{
-		Number:    api.Number,
-		Author:    api.Author.User.ID,
-		State:     api.State,
-		CreatedAt: api.CreatedAt,
-		UpdatedAt: api.UpdatedAt,
-		FetchedAt: fetchedAt,
-		Labels:    []string{},
-		Contexts:  []github.BuildContext{},
+		Number:            api.Number,
+		Author:            c.userIdentifier(api.Author.Login, api.Author.User.ID),
+		AuthorType:        c.authorType(api.Author.Typename, api.Author.Login),
+		AuthorAssociation: api.AuthorAssociation,
+		State:             api.State,
+		CreatedAt:         api.CreatedAt,
+		UpdatedAt:         api.UpdatedAt,
+		ClosedAt:          api.ClosedAt,
+		MergedAt:          api.MergedAt,
+		FetchedAt:         fetchedAt,
+		Assignees:         []string{},
+		Contexts:          []github.BuildContext{},
+		LinkedIssues:      []github.IssueReference{},
+	}
+
+	if api.MergedBy != nil {
+		pr.MergedBy = c.userIdentifier(api.MergedBy.Login, api.MergedBy.User.ID)
 	}
 
-	if c.realnames {
-		pr.Author = api.Author.Login
+	if api.Milestone != nil {
+		pr.Milestone = api.Milestone.Number
+	}
+
+	pr.Labels = c.itemLabels(owner, name, api.Number, api.Labels)
+
+	comments := c.summarizeComments(api.Comments, api.Author.Login, api.CreatedAt)
+	pr.Comments = comments.total
+	pr.LastCommentAt = comments.lastCommentAt
+	pr.LastCommentBy = comments.lastCommentBy
+	pr.AwaitingResponseSince = comments.awaitingResponseSince
+
+	for _, assignee := range api.Assignees.Nodes {
+		pr.Assignees = append(pr.Assignees, c.userIdentifier(assignee.Login, assignee.ID))
 	}
 
-	for _, label := range api.Labels.Nodes {
-		pr.Labels = append(pr.Labels, label.Name)
+	for _, issue := range api.ClosingIssuesReferences.Nodes {
+		pr.LinkedIssues = append(pr.LinkedIssues, github.IssueReference{
+			Repository: issue.Repository.NameWithOwner,
+			Number:     issue.Number,
+		})
 	}
 
 	if len(api.Commits.Nodes) > 0 {
@@ -102,7 +156,7 @@ func (c *Client) GetRepositoryPullRequests(owner string, name string, numbers []
 	now := time.Now()
 	prs := []github.PullRequest{}
 	for _, pr := range q.GetAll() {
-		prs = append(prs, c.convertPullRequest(pr, now))
+		prs = append(prs, c.convertPullRequest(owner, name, pr, now))
 	}
 
 	return prs, nil
@@ -161,7 +215,7 @@ func (c *Client) ListPullRequests(owner string, name string, states []githubv4.P
 	now := time.Now()
 	prs := []github.PullRequest{}
 	for _, node := range q.Repository.PullRequests.Nodes {
-		prs = append(prs, c.convertPullRequest(node, now))
+		prs = append(prs, c.convertPullRequest(owner, name, node, now))
 	}
 
 	cursor = ""
@@ -4,6 +4,8 @@
 package client
 
 import (
+	"strings"
+
 	"github.com/shurcooL/githubv4"
 	"github.com/sirupsen/logrus"
 )
@@ -19,14 +21,32 @@ type repositoryInfoQuery struct {
 		Watchers struct {
 			TotalCount int
 		}
-		IsPrivate  bool
-		IsArchived bool
-		IsDisabled bool
-		IsFork     bool
-		IsLocked   bool
-		IsMirror   bool
-		IsTemplate bool
-		Languages  struct {
+		IsPrivate   bool
+		IsArchived  bool
+		IsDisabled  bool
+		IsFork      bool
+		IsLocked    bool
+		IsMirror    bool
+		IsTemplate  bool
+		Visibility  githubv4.RepositoryVisibility
+		HomepageURL string
+		LicenseInfo *struct {
+			SpdxID string
+		}
+		DefaultBranchRef *struct {
+			Name string
+		}
+		PrimaryLanguage *struct {
+			Name string
+		}
+		RepositoryTopics struct {
+			Nodes []struct {
+				Topic struct {
+					Name string
+				}
+			}
+		} `graphql:"repositoryTopics(first: 20)"`
+		Languages struct {
 			Edges []struct {
 				Size int
 				Node struct {
@@ -51,6 +71,13 @@ type RepositoryInfo struct {
 	IsMirror   bool
 	IsTemplate bool
 	Languages  map[string]int
+
+	Topics        []string
+	License       string
+	DefaultBranch string
+	Visibility    string
+	Language      string
+	HasHomepage   bool
 }
 
 func (c *Client) RepositoryInfo(owner string, name string) (*RepositoryInfo, error) {
@@ -87,6 +114,26 @@ func (c *Client) RepositoryInfo(owner string, name string) (*RepositoryInfo, err
 		IsMirror:   q.Repository.IsMirror,
 		IsTemplate: q.Repository.IsTemplate,
 		Languages:  map[string]int{},
+
+		Topics:      []string{},
+		Visibility:  strings.ToLower(string(q.Repository.Visibility)),
+		HasHomepage: q.Repository.HomepageURL != "",
+	}
+
+	if q.Repository.LicenseInfo != nil {
+		info.License = q.Repository.LicenseInfo.SpdxID
+	}
+
+	if q.Repository.DefaultBranchRef != nil {
+		info.DefaultBranch = q.Repository.DefaultBranchRef.Name
+	}
+
+	if q.Repository.PrimaryLanguage != nil {
+		info.Language = q.Repository.PrimaryLanguage.Name
+	}
+
+	for _, node := range q.Repository.RepositoryTopics.Nodes {
+		info.Topics = append(info.Topics, node.Topic.Name)
 	}
 
 	for _, lang := range q.Repository.Languages.Edges {
@@ -96,34 +143,89 @@ func (c *Client) RepositoryInfo(owner string, name string) (*RepositoryInfo, err
 	return info, nil
 }
 
-type repositoriesNamesQuery struct {
+type ownedRepositoriesQuery struct {
 	RateLimit       rateLimit
 	RepositoryOwner struct {
 		Repositories struct {
 			Nodes []struct {
-				Name string
+				Name             string
+				IsFork           bool
+				IsLocked         bool
+				IsArchived       bool
+				IsPrivate        bool
+				RepositoryTopics struct {
+					Nodes []struct {
+						Topic struct {
+							Name string
+						}
+					}
+				} `graphql:"repositoryTopics(first: 20)"`
 			}
-		} `graphql:"repositories(last: 100, isFork: false, isLocked: false, affiliations: OWNER)"`
+			PageInfo struct {
+				EndCursor   githubv4.String
+				HasNextPage bool
+			}
+		} `graphql:"repositories(first: 100, after: $cursor, affiliations: OWNER)"`
 	} `graphql:"repositoryOwner(login: $login)"`
 }
 
-func (c *Client) RepositoriesNames(login string) ([]string, error) {
+type OwnedRepository struct {
+	Name       string
+	IsFork     bool
+	IsLocked   bool
+	IsArchived bool
+	IsPrivate  bool
+	Topics     []string
+}
+
+// OwnedRepositories lists all repositories owned by the given user or
+// organization. Filtering is left to the caller.
+func (c *Client) OwnedRepositories(login string) ([]OwnedRepository, error) {
 	variables := map[string]interface{}{
-		"login": githubv4.String(login),
+		"login":  githubv4.String(login),
+		"cursor": (*githubv4.String)(nil),
 	}
 
-	var q repositoriesNamesQuery
+	var q ownedRepositoriesQuery
 
-	err := c.client.Query(c.ctx, &q, variables)
+	repos := []OwnedRepository{}
 
-	if err != nil {
-		c.log.Error(err)
-		return nil, err
-	}
+	for {
+		err := c.client.Query(c.ctx, &q, variables)
+		c.countRequestFor(RequestKindOwner, login, q.RateLimit)
+
+		c.log.WithFields(logrus.Fields{
+			"login":  login,
+			"cursor": variables["cursor"],
+			"cost":   q.RateLimit.Cost,
+		}).Debugf("OwnedRepositories()")
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range q.RepositoryOwner.Repositories.Nodes {
+			repo := OwnedRepository{
+				Name:       node.Name,
+				IsFork:     node.IsFork,
+				IsLocked:   node.IsLocked,
+				IsArchived: node.IsArchived,
+				IsPrivate:  node.IsPrivate,
+				Topics:     []string{},
+			}
+
+			for _, topic := range node.RepositoryTopics.Nodes {
+				repo.Topics = append(repo.Topics, topic.Topic.Name)
+			}
+
+			repos = append(repos, repo)
+		}
+
+		if !q.RepositoryOwner.Repositories.PageInfo.HasNextPage {
+			break
+		}
 
-	repos := []string{}
-	for _, node := range q.RepositoryOwner.Repositories.Nodes {
-		repos = append(repos, node.Name)
+		variables["cursor"] = githubv4.NewString(q.RepositoryOwner.Repositories.PageInfo.EndCursor)
 	}
 
 	return repos, nil
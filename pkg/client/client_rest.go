@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const restBaseURL = "https://api.github.com/"
+
+// restGet performs a GET request against the GitHub REST API and decodes the
+// JSON response into dst. The request is accounted for the given owner/name.
+func (c *Client) restGet(owner string, name string, path string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, restBaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	c.countRESTRequest(owner, name, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
+
+// countRESTRequest records a REST request. REST calls have no point cost,
+// but are limited by their own request budget, which is reported in the
+// response headers.
+func (c *Client) countRESTRequest(owner string, name string, resp *http.Response) {
+	key := RequestTarget{Kind: RequestKindRepository, Name: fmt.Sprintf("%s/%s", owner, name)}
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.requests[key]++
+
+	if err == nil {
+		c.remainingRESTRequests = remaining
+	}
+}
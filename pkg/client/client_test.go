@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"testing"
+)
+
+func TestClientPseudonym(t *testing.T) {
+	testcases := []struct {
+		name     string
+		identity IdentityOptions
+		login    string
+		expected string
+	}{
+		{
+			name:     "empty login",
+			identity: IdentityOptions{PseudonymSecret: []byte("secret")},
+			login:    "",
+			expected: "",
+		},
+		{
+			name:     "login is hashed",
+			identity: IdentityOptions{PseudonymSecret: []byte("secret")},
+			login:    "alice",
+			expected: "4360c67bc8102511",
+		},
+		{
+			name:     "hash ignores the case of the login",
+			identity: IdentityOptions{PseudonymSecret: []byte("secret")},
+			login:    "Alice",
+			expected: "4360c67bc8102511",
+		},
+		{
+			name:     "hash depends on the secret",
+			identity: IdentityOptions{PseudonymSecret: []byte("other")},
+			login:    "alice",
+			expected: "8244fe1a0c99ceae",
+		},
+		{
+			name: "allowlisted logins are kept in clear text",
+			identity: IdentityOptions{
+				PseudonymSecret: []byte("secret"),
+				ClearTextLogins: []string{"Renovate"},
+			},
+			login:    "renovate",
+			expected: "renovate",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			c := &Client{identity: testcase.identity}
+
+			if pseudonym := c.pseudonym(testcase.login); pseudonym != testcase.expected {
+				t.Fatalf("Expected %q, got %q.", testcase.expected, pseudonym)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+type graphqlLabelEvent struct {
+	CreatedAt time.Time
+	Label     struct {
+		Name string
+	}
+}
+
+type graphqlStateEvent struct {
+	CreatedAt time.Time
+}
+
+type graphqlTimelineItems struct {
+	Nodes []struct {
+		Typename       string            `graphql:"__typename"`
+		LabeledEvent   graphqlLabelEvent `graphql:"... on LabeledEvent"`
+		UnlabeledEvent graphqlLabelEvent `graphql:"... on UnlabeledEvent"`
+		ClosedEvent    graphqlStateEvent `graphql:"... on ClosedEvent"`
+		ReopenedEvent  graphqlStateEvent `graphql:"... on ReopenedEvent"`
+	}
+	PageInfo struct {
+		EndCursor   githubv4.String
+		HasNextPage bool
+	}
+}
+
+func (t *graphqlTimelineItems) events() []github.TimelineEvent {
+	events := []github.TimelineEvent{}
+
+	for _, node := range t.Nodes {
+		switch node.Typename {
+		case "LabeledEvent":
+			events = append(events, github.TimelineEvent{
+				Type:      github.TimelineEventLabeled,
+				Label:     node.LabeledEvent.Label.Name,
+				CreatedAt: node.LabeledEvent.CreatedAt,
+			})
+		case "UnlabeledEvent":
+			events = append(events, github.TimelineEvent{
+				Type:      github.TimelineEventUnlabeled,
+				Label:     node.UnlabeledEvent.Label.Name,
+				CreatedAt: node.UnlabeledEvent.CreatedAt,
+			})
+		case "ClosedEvent":
+			events = append(events, github.TimelineEvent{
+				Type:      github.TimelineEventClosed,
+				CreatedAt: node.ClosedEvent.CreatedAt,
+			})
+		case "ReopenedEvent":
+			events = append(events, github.TimelineEvent{
+				Type:      github.TimelineEventReopened,
+				CreatedAt: node.ReopenedEvent.CreatedAt,
+			})
+		}
+	}
+
+	return events
+}
+
+type timelineQuery struct {
+	RateLimit  rateLimit
+	Repository struct {
+		IssueOrPullRequest struct {
+			Issue struct {
+				TimelineItems graphqlTimelineItems `graphql:"timelineItems(first: 100, after: $cursor, since: $since, itemTypes: [LABELED_EVENT, UNLABELED_EVENT, CLOSED_EVENT, REOPENED_EVENT])"`
+			} `graphql:"... on Issue"`
+			PullRequest struct {
+				TimelineItems graphqlTimelineItems `graphql:"timelineItems(first: 100, after: $cursor, since: $since, itemTypes: [LABELED_EVENT, UNLABELED_EVENT, CLOSED_EVENT, REOPENED_EVENT])"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"issueOrPullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// ListTimelineEvents returns all label and state change events of an issue
+// or pull request. If since is given, only events after it are returned.
+func (c *Client) ListTimelineEvents(owner string, name string, number int, since *time.Time) ([]github.TimelineEvent, error) {
+	variables := map[string]interface{}{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(name),
+		"number": githubv4.Int(number),
+		"cursor": (*githubv4.String)(nil),
+		"since":  (*githubv4.DateTime)(nil),
+	}
+
+	if since != nil {
+		variables["since"] = githubv4.DateTime{Time: *since}
+	}
+
+	events := []github.TimelineEvent{}
+
+	for {
+		var q timelineQuery
+
+		err := c.client.Query(c.ctx, &q, variables)
+		c.countRequest(owner, name, q.RateLimit)
+
+		c.log.WithFields(logrus.Fields{
+			"owner":  owner,
+			"name":   name,
+			"number": number,
+			"cost":   q.RateLimit.Cost,
+		}).Debugf("ListTimelineEvents()")
+
+		if err != nil {
+			return nil, err
+		}
+
+		// only one of the two is set, depending on the item type
+		items := q.Repository.IssueOrPullRequest.Issue.TimelineItems
+		if len(items.Nodes) == 0 {
+			items = q.Repository.IssueOrPullRequest.PullRequest.TimelineItems
+		}
+
+		events = append(events, items.events()...)
+
+		if !items.PageInfo.HasNextPage {
+			break
+		}
+
+		variables["cursor"] = githubv4.String(items.PageInfo.EndCursor)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/sirupsen/logrus"
+)
+
+type trafficDay struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+	Uniques   int       `json:"uniques"`
+}
+
+type trafficViews struct {
+	Count   int          `json:"count"`
+	Uniques int          `json:"uniques"`
+	Views   []trafficDay `json:"views"`
+}
+
+type trafficClones struct {
+	Count   int          `json:"count"`
+	Uniques int          `json:"uniques"`
+	Clones  []trafficDay `json:"clones"`
+}
+
+type trafficReferrer struct {
+	Referrer string `json:"referrer"`
+	Count    int    `json:"count"`
+	Uniques  int    `json:"uniques"`
+}
+
+type RepositoryTraffic struct {
+	Views          int
+	UniqueVisitors int
+	Clones         int
+	UniqueCloners  int
+	Referrers      map[string]int
+	DailyViews     []github.TrafficDay
+	DailyClones    []github.TrafficDay
+}
+
+// RepositoryTraffic returns the traffic statistics of the last 14 days. This
+// requires push access to the repository and is only available via the REST API.
+func (c *Client) RepositoryTraffic(owner string, name string) (*RepositoryTraffic, error) {
+	base := fmt.Sprintf("repos/%s/%s/traffic", owner, name)
+
+	c.log.WithFields(logrus.Fields{
+		"owner": owner,
+		"name":  name,
+	}).Debugf("RepositoryTraffic()")
+
+	var views trafficViews
+	if err := c.restGet(owner, name, base+"/views", &views); err != nil {
+		return nil, fmt.Errorf("failed to fetch views: %w", err)
+	}
+
+	var clones trafficClones
+	if err := c.restGet(owner, name, base+"/clones", &clones); err != nil {
+		return nil, fmt.Errorf("failed to fetch clones: %w", err)
+	}
+
+	var referrers []trafficReferrer
+	if err := c.restGet(owner, name, base+"/popular/referrers", &referrers); err != nil {
+		return nil, fmt.Errorf("failed to fetch referrers: %w", err)
+	}
+
+	traffic := &RepositoryTraffic{
+		Views:          views.Count,
+		UniqueVisitors: views.Uniques,
+		Clones:         clones.Count,
+		UniqueCloners:  clones.Uniques,
+		Referrers:      map[string]int{},
+		DailyViews:     convertTrafficDays(views.Views),
+		DailyClones:    convertTrafficDays(clones.Clones),
+	}
+
+	for _, referrer := range referrers {
+		traffic.Referrers[referrer.Referrer] = referrer.Count
+	}
+
+	return traffic, nil
+}
+
+func convertTrafficDays(days []trafficDay) []github.TrafficDay {
+	result := []github.TrafficDay{}
+	for _, day := range days {
+		result = append(result, github.TrafficDay{
+			Date:    day.Timestamp.UTC().Format(time.DateOnly),
+			Count:   day.Count,
+			Uniques: day.Uniques,
+		})
+	}
+
+	return result
+}
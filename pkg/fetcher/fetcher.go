@@ -21,18 +21,35 @@ type Fetcher struct {
 	pullRequestQueues map[string]prioritizedIntegerQueue
 	issueQueues       map[string]prioritizedIntegerQueue
 	milestoneQueues   map[string]prioritizedIntegerQueue
+	discussionQueues  map[string]prioritizedIntegerQueue
+	projects          map[string]*github.Project
+	projectJobQueues  map[string]jobQueue
+	organizations     map[string]*github.Organization
+	orgJobQueues      map[string]jobQueue
 	lock              sync.RWMutex
 }
 
 func NewFetcher(client *client.Client, repos map[string]*github.Repository, log logrus.FieldLogger) *Fetcher {
+	// copy the map, so that adding/removing repositories later on does
+	// not affect the caller
+	repositories := map[string]*github.Repository{}
+	for fullName, repo := range repos {
+		repositories[fullName] = repo
+	}
+
 	return &Fetcher{
 		client:            client,
 		log:               log,
-		repositories:      repos,
+		repositories:      repositories,
 		jobQueues:         makeJobQueues(repos),
 		pullRequestQueues: makePrioritizedIntegerQueues(repos),
 		issueQueues:       makePrioritizedIntegerQueues(repos),
 		milestoneQueues:   makePrioritizedIntegerQueues(repos),
+		discussionQueues:  makePrioritizedIntegerQueues(repos),
+		projects:          map[string]*github.Project{},
+		projectJobQueues:  map[string]jobQueue{},
+		organizations:     map[string]*github.Organization{},
+		orgJobQueues:      map[string]jobQueue{},
 		lock:              sync.RWMutex{},
 	}
 }
@@ -57,10 +74,89 @@ func makeJobQueues(repos map[string]*github.Repository) map[string]jobQueue {
 	return queues
 }
 
+// AddRepository registers a repository at runtime, so that jobs can be
+// enqueued for it.
+func (f *Fetcher) AddRepository(r *github.Repository) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	fullName := r.FullName()
+	if _, exists := f.repositories[fullName]; exists {
+		return
+	}
+
+	f.repositories[fullName] = r
+	f.jobQueues[fullName] = jobQueue{}
+
+	for _, queues := range f.itemQueues() {
+		queues[fullName] = newPrioritizedIntegerQueue()
+	}
+}
+
+// RemoveRepository unregisters a repository and drops all of its pending
+// jobs. Jobs enqueued for the repository afterwards are ignored.
+func (f *Fetcher) RemoveRepository(r *github.Repository) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	fullName := r.FullName()
+
+	delete(f.repositories, fullName)
+	delete(f.jobQueues, fullName)
+
+	for _, queues := range f.itemQueues() {
+		delete(queues, fullName)
+	}
+}
+
+func (f *Fetcher) itemQueues() []map[string]prioritizedIntegerQueue {
+	return []map[string]prioritizedIntegerQueue{
+		f.pullRequestQueues,
+		f.issueQueues,
+		f.milestoneQueues,
+		f.discussionQueues,
+	}
+}
+
+// AddProject registers a project, so that jobs can be enqueued for it.
+func (f *Fetcher) AddProject(p *github.Project) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.projects[p.FullName()] = p
+	f.projectJobQueues[p.FullName()] = jobQueue{}
+}
+
+// AddOrganization registers an organization, so that jobs can be enqueued for it.
+func (f *Fetcher) AddOrganization(o *github.Organization) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.organizations[o.Login] = o
+	f.orgJobQueues[o.Login] = jobQueue{}
+}
+
 func (f *Fetcher) EnqueueRepoUpdate(r *github.Repository) {
 	f.enqueueJob(r, updateRepoInfoJobKey, nil)
 }
 
+func (f *Fetcher) EnqueueTrafficUpdate(r *github.Repository) {
+	f.enqueueJob(r, updateTrafficJobKey, nil)
+}
+
+// EnqueueTimelineUpdate fetches the timelines of all changed issues and PRs.
+func (f *Fetcher) EnqueueTimelineUpdate(r *github.Repository) {
+	f.enqueueJob(r, updateTimelinesJobKey, nil)
+}
+
+// EnqueueDeploymentScan fetches all deployments that were created within
+// the given window.
+func (f *Fetcher) EnqueueDeploymentScan(r *github.Repository, window time.Duration) {
+	f.enqueueJob(r, scanDeploymentsJobKey, scanDeploymentsJobMeta{
+		since: time.Now().Add(-window),
+	})
+}
+
 func (f *Fetcher) EnqueueLabelUpdate(r *github.Repository) {
 	f.enqueueJob(r, updateLabelsJobKey, nil)
 }
@@ -113,13 +209,76 @@ func (f *Fetcher) enqueueUpdatedMilestones(r *github.Repository, numbers []int)
 	})
 }
 
+func (f *Fetcher) EnqueueUpdatedDiscussions(r *github.Repository) {
+	f.enqueueJob(r, findUpdatedDiscussionsJobKey, nil)
+}
+
+func (f *Fetcher) EnqueueDiscussionScan(r *github.Repository, max int) {
+	f.enqueueJob(r, scanDiscussionsJobKey, scanDiscussionsJobMeta{
+		max: max,
+	})
+}
+
+func (f *Fetcher) enqueueUpdatedDiscussions(r *github.Repository, numbers []int) {
+	f.enqueueJob(r, updateDiscussionsJobKey, updateDiscussionsJobMeta{
+		numbers: numbers,
+	})
+}
+
 func (f *Fetcher) enqueueJob(r *github.Repository, key string, data interface{}) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
+	queue, ok := f.jobQueues[r.FullName()]
+	if !ok {
+		f.log.WithField("repo", r.FullName()).WithField("job", key).Debug("Ignoring job for unknown repository.")
+		return
+	}
+
 	f.log.WithField("repo", r.FullName()).WithField("job", key).Debug("Enqueueing job.")
 
-	f.jobQueues[r.FullName()][key] = data
+	queue[key] = data
+}
+
+// EnqueueProjectScan starts a new scan of the project's items, unless a scan
+// is already in progress; replacing it would discard the items collected so
+// far and large projects would never be scanned completely.
+func (f *Fetcher) EnqueueProjectScan(p *github.Project) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	queue := f.projectJobQueues[p.FullName()]
+	if _, exists := queue[scanProjectItemsJobKey]; exists {
+		f.log.WithField("project", p.FullName()).Debug("Project scan is already in progress.")
+		return
+	}
+
+	f.log.WithField("project", p.FullName()).WithField("job", scanProjectItemsJobKey).Debug("Enqueueing job.")
+
+	queue[scanProjectItemsJobKey] = scanProjectItemsJobMeta{}
+}
+
+func (f *Fetcher) enqueueProjectJob(p *github.Project, key string, data interface{}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.log.WithField("project", p.FullName()).WithField("job", key).Debug("Enqueueing job.")
+
+	f.projectJobQueues[p.FullName()][key] = data
+}
+
+func (f *Fetcher) EnqueueOrganizationUpdate(o *github.Organization) {
+	f.enqueueOrganizationJob(o, updateOrganizationInfoJobKey, nil)
+	f.enqueueOrganizationJob(o, updateOrganizationOutsideCollaboratorsJobKey, nil)
+}
+
+func (f *Fetcher) enqueueOrganizationJob(o *github.Organization, key string, data interface{}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.log.WithField("org", o.Login).WithField("job", key).Debug("Enqueueing job.")
+
+	f.orgJobQueues[o.Login][key] = data
 }
 
 func (f *Fetcher) EnqueuePriorityPullRequests(r *github.Repository, numbers []int) {
@@ -146,15 +305,25 @@ func (f *Fetcher) EnqueueRegularMilestones(r *github.Repository, numbers []int)
 	f.enqueue(r, numbers, f.milestoneQueues, false)
 }
 
+func (f *Fetcher) EnqueuePriorityDiscussions(r *github.Repository, numbers []int) {
+	f.enqueue(r, numbers, f.discussionQueues, true)
+}
+
+func (f *Fetcher) EnqueueRegularDiscussions(r *github.Repository, numbers []int) {
+	f.enqueue(r, numbers, f.discussionQueues, false)
+}
+
 func (f *Fetcher) enqueue(r *github.Repository, numbers []int, queues map[string]prioritizedIntegerQueue, priority bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// the repository might have been removed in the meantime
 	queue, ok := queues[r.FullName()]
 	if !ok {
-		f.log.Fatalf("No queue defined for repository %s", r.FullName())
+		f.log.WithField("repo", r.FullName()).Debug("Ignoring items for unknown repository.")
+		return
 	}
 
-	f.lock.Lock()
-	defer f.lock.Unlock()
-
 	f.log.WithField("repo", r.FullName()).Debugf("Enqueueing %d items for updating.", len(numbers))
 
 	if priority {
@@ -188,15 +357,24 @@ func (f *Fetcher) RegularMilestoneQueueSize(r *github.Repository) int {
 	return f.queueSize(r, f.milestoneQueues, false)
 }
 
+func (f *Fetcher) PriorityDiscussionQueueSize(r *github.Repository) int {
+	return f.queueSize(r, f.discussionQueues, true)
+}
+
+func (f *Fetcher) RegularDiscussionQueueSize(r *github.Repository) int {
+	return f.queueSize(r, f.discussionQueues, false)
+}
+
 func (f *Fetcher) queueSize(r *github.Repository, queues map[string]prioritizedIntegerQueue, priority bool) int {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	// retired repositories have no queues anymore
 	queue, ok := queues[r.FullName()]
 	if !ok {
-		f.log.Fatalf("No queue defined for repository %s", r.FullName())
+		return 0
 	}
 
-	f.lock.RLock()
-	defer f.lock.RUnlock()
-
 	if priority {
 		return queue.prioritySize()
 	} else {
@@ -222,6 +400,27 @@ func (f *Fetcher) Worker() {
 			continue
 		}
 
+		// jobs for projects and organizations are not bound to any repository
+		project, job, data := f.getNextProjectJob()
+		if project != nil {
+			err := f.processProjectJob(project, job, data)
+			if err != nil {
+				f.log.Errorf("Failed to process job: %v", err)
+			}
+
+			continue
+		}
+
+		org, job := f.getNextOrganizationJob()
+		if org != nil {
+			err := f.processOrganizationJob(org, job)
+			if err != nil {
+				f.log.Errorf("Failed to process job: %v", err)
+			}
+
+			continue
+		}
+
 		// if there was no job, try to create a job to update the existing
 		// numbered PRs
 		repo, candidates := f.getPullRequestBatch(10, client.MaxPullRequestsPerQuery)
@@ -246,6 +445,13 @@ func (f *Fetcher) Worker() {
 			continue
 		}
 
+		// try batching up discussions next
+		repo, candidates = f.getDiscussionBatch(10, client.MaxDiscussionsPerQuery)
+		if repo != nil {
+			f.enqueueUpdatedDiscussions(repo, candidates)
+			continue
+		}
+
 		// no repo has enough items for a good batch; in order to not burn
 		// CPU cycles, we will wait a bit and check again. But we don't wait
 		// forever, otherwise repositories with very few PRs might never get
@@ -276,6 +482,12 @@ func (f *Fetcher) Worker() {
 			continue
 		}
 
+		repo, candidates = f.getDiscussionBatch(1, client.MaxDiscussionsPerQuery)
+		if repo != nil {
+			f.enqueueUpdatedDiscussions(repo, candidates)
+			continue
+		}
+
 		// all repository queues are entirely empty, we finished the
 		// force flush and can remember the time; this means on the next
 		// iteration we will begin to sleep again.
@@ -293,6 +505,7 @@ var priorityJobs = []string{
 	scanIssuesJobKey,
 	scanPullRequestsJobKey,
 	scanMilestonesJobKey,
+	scanDiscussionsJobKey,
 }
 
 func (f *Fetcher) getNextJob() (*github.Repository, string, interface{}) {
@@ -314,6 +527,32 @@ func (f *Fetcher) getNextJob() (*github.Repository, string, interface{}) {
 	return nil, "", nil
 }
 
+func (f *Fetcher) getNextProjectJob() (*github.Project, string, interface{}) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	for fullName, queue := range f.projectJobQueues {
+		for job, data := range queue {
+			return f.projects[fullName], job, data
+		}
+	}
+
+	return nil, "", nil
+}
+
+func (f *Fetcher) getNextOrganizationJob() (*github.Organization, string) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	for login, queue := range f.orgJobQueues {
+		for job := range queue {
+			return f.organizations[login], job
+		}
+	}
+
+	return nil, ""
+}
+
 func (f *Fetcher) getPullRequestBatch(minBatchSize int, maxBatchSize int) (*github.Repository, []int) {
 	return f.getBatch(f.pullRequestQueues, minBatchSize, maxBatchSize)
 }
@@ -326,6 +565,10 @@ func (f *Fetcher) getMilestoneBatch(minBatchSize int, maxBatchSize int) (*github
 	return f.getBatch(f.milestoneQueues, minBatchSize, maxBatchSize)
 }
 
+func (f *Fetcher) getDiscussionBatch(minBatchSize int, maxBatchSize int) (*github.Repository, []int) {
+	return f.getBatch(f.discussionQueues, minBatchSize, maxBatchSize)
+}
+
 func (f *Fetcher) getBatch(queues map[string]prioritizedIntegerQueue, minBatchSize int, maxBatchSize int) (*github.Repository, []int) {
 	f.lock.RLock()
 	defer f.lock.RUnlock()
@@ -354,6 +597,12 @@ func (f *Fetcher) processJob(repo *github.Repository, job string, data interface
 		err = f.processUpdateLabelsJob(repo, log, job)
 	case updateRepoInfoJobKey:
 		err = f.processUpdateRepoInfos(repo, log, job)
+	case updateTrafficJobKey:
+		err = f.processUpdateTrafficJob(repo, log, job)
+	case scanDeploymentsJobKey:
+		err = f.processScanDeploymentsJob(repo, log, job, data)
+	case updateTimelinesJobKey:
+		err = f.processUpdateTimelinesJob(repo, log, job)
 	case updatePullRequestsJobKey:
 		err = f.processUpdatePullRequestsJob(repo, log, job, data)
 	case findUpdatedPullRequestsJobKey:
@@ -372,6 +621,12 @@ func (f *Fetcher) processJob(repo *github.Repository, job string, data interface
 		err = f.processFindUpdatedMilestonesJob(repo, log, job)
 	case scanMilestonesJobKey:
 		err = f.processScanMilestonesJob(repo, log, job, data)
+	case updateDiscussionsJobKey:
+		err = f.processUpdateDiscussionsJob(repo, log, job, data)
+	case findUpdatedDiscussionsJobKey:
+		err = f.processFindUpdatedDiscussionsJob(repo, log, job)
+	case scanDiscussionsJobKey:
+		err = f.processScanDiscussionsJob(repo, log, job, data)
 	default:
 		f.log.Fatalf("Encountered unknown job type %q for repo %q", job, repo.FullName())
 	}
@@ -379,6 +634,40 @@ func (f *Fetcher) processJob(repo *github.Repository, job string, data interface
 	return err
 }
 
+func (f *Fetcher) processProjectJob(project *github.Project, job string, data interface{}) error {
+	var err error
+
+	log := f.log.WithField("project", project.FullName()).WithField("job", job)
+	log.Debug("Processing job…")
+
+	switch job {
+	case scanProjectItemsJobKey:
+		err = f.processScanProjectItemsJob(project, log, job, data)
+	default:
+		f.log.Fatalf("Encountered unknown job type %q for project %q", job, project.FullName())
+	}
+
+	return err
+}
+
+func (f *Fetcher) processOrganizationJob(org *github.Organization, job string) error {
+	var err error
+
+	log := f.log.WithField("org", org.Login).WithField("job", job)
+	log.Debug("Processing job…")
+
+	switch job {
+	case updateOrganizationInfoJobKey:
+		err = f.processUpdateOrganizationInfoJob(org, log, job)
+	case updateOrganizationOutsideCollaboratorsJobKey:
+		err = f.processUpdateOrganizationOutsideCollaboratorsJob(org, log, job)
+	default:
+		f.log.Fatalf("Encountered unknown job type %q for organization %q", job, org.Login)
+	}
+
+	return err
+}
+
 func (f *Fetcher) removeJob(repo *github.Repository, job string) {
 	f.log.WithField("job", job).Debugf("Removing job.")
 
@@ -390,6 +679,24 @@ func (f *Fetcher) removeJob(repo *github.Repository, job string) {
 	delete(f.jobQueues[fullName], job)
 }
 
+func (f *Fetcher) removeProjectJob(project *github.Project, job string) {
+	f.log.WithField("job", job).Debugf("Removing job.")
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	delete(f.projectJobQueues[project.FullName()], job)
+}
+
+func (f *Fetcher) removeOrganizationJob(org *github.Organization, job string) {
+	f.log.WithField("job", job).Debugf("Removing job.")
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	delete(f.orgJobQueues[org.Login], job)
+}
+
 func (f *Fetcher) dequeuePullRequests(repo *github.Repository, numbers []int) {
 	f.log.Debugf("Removing %d fetched PRs.", len(numbers))
 	f.dequeue(repo, f.pullRequestQueues, numbers)
@@ -405,6 +712,11 @@ func (f *Fetcher) dequeueMilestones(repo *github.Repository, numbers []int) {
 	f.dequeue(repo, f.milestoneQueues, numbers)
 }
 
+func (f *Fetcher) dequeueDiscussions(repo *github.Repository, numbers []int) {
+	f.log.Debugf("Removing %d fetched discussions.", len(numbers))
+	f.dequeue(repo, f.discussionQueues, numbers)
+}
+
 func (f *Fetcher) dequeue(repo *github.Repository, queues map[string]prioritizedIntegerQueue, numbers []int) {
 	fullName := repo.FullName()
 
@@ -14,6 +14,7 @@ import (
 const (
 	updateLabelsJobKey   = "update-labels"
 	updateRepoInfoJobKey = "update-repository-info"
+	updateTrafficJobKey  = "update-traffic"
 )
 
 type jobQueue map[string]interface{}
@@ -52,6 +53,40 @@ func (f *Fetcher) processUpdateRepoInfos(repo *github.Repository, log logrus.Fie
 			r.IsMirror = info.IsMirror
 			r.IsTemplate = info.IsTemplate
 			r.Languages = info.Languages
+			r.Topics = info.Topics
+			r.License = info.License
+			r.DefaultBranch = info.DefaultBranch
+			r.Visibility = info.Visibility
+			r.Language = info.Language
+			r.HasHomepage = info.HasHomepage
+
+			return nil
+		})
+	}
+
+	f.removeJob(repo, job)
+
+	return err
+}
+
+// processUpdateTrafficJob fetches the repository's traffic statistics.
+func (f *Fetcher) processUpdateTrafficJob(repo *github.Repository, log logrus.FieldLogger, job string) error {
+	traffic, err := f.client.RepositoryTraffic(repo.Owner, repo.Name)
+
+	if traffic != nil {
+		log.Debugf("Fetched %d referrers.", len(traffic.Referrers))
+
+		_ = repo.Locked(func(r *github.Repository) error {
+			r.Traffic = &github.Traffic{
+				Views:          traffic.Views,
+				UniqueVisitors: traffic.UniqueVisitors,
+				Clones:         traffic.Clones,
+				UniqueCloners:  traffic.UniqueCloners,
+				Referrers:      traffic.Referrers,
+				DailyViews:     traffic.DailyViews,
+				DailyClones:    traffic.DailyClones,
+				FetchedAt:      time.Now(),
+			}
 
 			return nil
 		})
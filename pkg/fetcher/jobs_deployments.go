@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package fetcher
+
+import (
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	scanDeploymentsJobKey = "scan-deployments"
+)
+
+type scanDeploymentsJobMeta struct {
+	cursor      string
+	since       time.Time
+	deployments []github.Deployment
+}
+
+// processScanDeploymentsJob lists all deployments created after meta.since,
+// one page at a time. Deployments are collected across pages and only replace
+// the repository's current deployments once all pages have been fetched.
+func (f *Fetcher) processScanDeploymentsJob(repo *github.Repository, log logrus.FieldLogger, job string, data interface{}) error {
+	meta := data.(scanDeploymentsJobMeta)
+
+	deployments, cursor, err := f.client.ListDeployments(repo.Owner, repo.Name, meta.cursor)
+
+	// always delete the job, no matter the outcome; the refresh worker
+	// will schedule a new scan later on
+	f.removeJob(repo, job)
+
+	if err != nil {
+		return err
+	}
+
+	// deployments are sorted newest first, so we can stop as soon as we
+	// reach the end of the time window
+	for _, deployment := range deployments {
+		if deployment.CreatedAt.Before(meta.since) {
+			cursor = ""
+			break
+		}
+
+		meta.deployments = append(meta.deployments, deployment)
+	}
+
+	log.WithField("new-cursor", cursor).Debugf("Fetched %d deployments.", len(meta.deployments))
+
+	// queue the query for the next page
+	if cursor != "" {
+		f.enqueueJob(repo, job, scanDeploymentsJobMeta{
+			cursor:      cursor,
+			since:       meta.since,
+			deployments: meta.deployments,
+		})
+
+		return nil
+	}
+
+	repo.SetDeployments(meta.deployments)
+
+	return nil
+}
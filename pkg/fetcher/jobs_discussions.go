@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package fetcher
+
+import (
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	scanDiscussionsJobKey        = "scan-discussions"
+	updateDiscussionsJobKey      = "update-discussions"
+	findUpdatedDiscussionsJobKey = "find-updated-discussions"
+)
+
+type updateDiscussionsJobMeta struct {
+	numbers []int
+}
+
+// processUpdateDiscussionsJob updates a list of already fetched
+// discussions to ensure they stay up to date. This is done for all open
+// discussions.
+func (f *Fetcher) processUpdateDiscussionsJob(repo *github.Repository, log logrus.FieldLogger, job string, data interface{}) error {
+	meta := data.(updateDiscussionsJobMeta)
+
+	discussions, err := f.client.GetRepositoryDiscussions(repo.Owner, repo.Name, meta.numbers)
+
+	fetchedNumbers := []int{}
+	fetchedNumbersMap := map[int]struct{}{}
+	for _, discussion := range discussions {
+		fetchedNumbers = append(fetchedNumbers, discussion.Number)
+		fetchedNumbersMap[discussion.Number] = struct{}{}
+	}
+
+	log.Debugf("Fetched %d out of %d discussions.", len(fetchedNumbers), len(meta.numbers))
+
+	deleted := []int{}
+	for _, number := range meta.numbers {
+		if _, ok := fetchedNumbersMap[number]; !ok {
+			deleted = append(deleted, number)
+		}
+	}
+
+	if len(discussions) > 0 {
+		repo.AddDiscussions(discussions)
+	}
+
+	// only delete not found discussions from our local cache if the request was a success, otherwise
+	// we would remove all discussions if e.g. GitHub is unavailable
+	if err == nil && len(deleted) > 0 {
+		repo.DeleteDiscussions(deleted)
+	}
+
+	f.removeJob(repo, job)
+	f.dequeueDiscussions(repo, meta.numbers)
+
+	return err
+}
+
+// processFindUpdatedDiscussionsJob fetches the 100 most recently updated
+// discussions in the given repository and updates repo. The job will be removed
+// from the job queue afterwards and all fetched discussions will be removed from
+// the priority/regular discussion queues.
+func (f *Fetcher) processFindUpdatedDiscussionsJob(repo *github.Repository, log logrus.FieldLogger, job string) error {
+	fetchedNumbers := []int{}
+
+	discussions, _, err := f.client.ListDiscussions(repo.Owner, repo.Name, nil, "")
+	for _, discussion := range discussions {
+		fetchedNumbers = append(fetchedNumbers, discussion.Number)
+	}
+
+	log.Debugf("Fetched %d recently updated discussions.", len(fetchedNumbers))
+
+	repo.AddDiscussions(discussions)
+
+	f.removeJob(repo, job)
+	f.dequeueDiscussions(repo, fetchedNumbers)
+
+	return err
+}
+
+type scanDiscussionsJobMeta struct {
+	max     int
+	fetched int
+	cursor  string
+}
+
+// processScanDiscussionsJob is the initial job for every repository.
+// It lists all existing discussions and adds them to repo.
+//
+// Because the initial scan is vital for proper functioning of every
+// other job, this job must succeed before anything else can happen
+// with a repository. For this reason a failed scan job is re-queued
+// a few seconds later.
+func (f *Fetcher) processScanDiscussionsJob(repo *github.Repository, log logrus.FieldLogger, job string, data interface{}) error {
+	meta := data.(scanDiscussionsJobMeta)
+	fullName := repo.FullName()
+	fetchedNumbers := []int{}
+
+	discussions, cursor, err := f.client.ListDiscussions(repo.Owner, repo.Name, nil, meta.cursor)
+
+	// if a max limit was set, enforce it (using ">=" here makes
+	// it so that we stop cleanly when the list of discussions is exactly
+	// the right amount that was left to fetch)
+	if meta.max > 0 && len(discussions)+meta.fetched >= meta.max {
+		discussions = discussions[:meta.max-meta.fetched]
+		cursor = ""
+	}
+
+	for _, discussion := range discussions {
+		fetchedNumbers = append(fetchedNumbers, discussion.Number)
+	}
+
+	repo.AddDiscussions(discussions)
+	f.dequeueDiscussions(repo, fetchedNumbers)
+
+	// always delete the job, no matter the outcome
+	f.lock.Lock()
+	delete(f.jobQueues[fullName], job)
+	f.lock.Unlock()
+
+	// batch query was successful
+	if err == nil {
+		log.WithField("new-cursor", cursor).Debugf("Fetched %d discussions.", len(discussions))
+
+		// queue the query for the next page
+		if cursor != "" {
+			f.enqueueJob(repo, job, scanDiscussionsJobMeta{
+				max:     meta.max,
+				fetched: meta.fetched + len(discussions),
+				cursor:  cursor,
+			})
+		}
+
+		return nil
+	}
+
+	retryAfter := 30 * time.Second
+	log.Errorf("Failed to list discussions, will retry in %s: %v", retryAfter.String(), err)
+
+	// query failed, re-try later
+	go func() {
+		time.Sleep(retryAfter)
+		f.enqueueJob(repo, job, data)
+	}()
+
+	return err
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package fetcher
+
+import (
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	updateOrganizationInfoJobKey                 = "update-organization-info"
+	updateOrganizationOutsideCollaboratorsJobKey = "update-organization-outside-collaborators"
+)
+
+// processUpdateOrganizationInfoJob fetches the organization's members,
+// pending invitations and teams.
+func (f *Fetcher) processUpdateOrganizationInfoJob(org *github.Organization, log logrus.FieldLogger, job string) error {
+	now := time.Now()
+
+	info, err := f.client.OrganizationInfo(org.Login)
+
+	if info != nil {
+		log.Debugf("Fetched %d teams.", len(info.Teams))
+
+		_ = org.Locked(func(o *github.Organization) error {
+			o.FetchedAt = &now
+			o.Members = info.Members
+			o.PendingInvitations = info.PendingInvitations
+			o.Teams = info.Teams
+
+			return nil
+		})
+	}
+
+	f.removeOrganizationJob(org, job)
+
+	return err
+}
+
+// processUpdateOrganizationOutsideCollaboratorsJob counts the distinct
+// outside collaborators across all of the organization's repositories.
+func (f *Fetcher) processUpdateOrganizationOutsideCollaboratorsJob(org *github.Organization, log logrus.FieldLogger, job string) error {
+	count, skipped, err := f.client.OrganizationOutsideCollaborators(org.Login)
+
+	if err == nil {
+		log.Debugf("Found %d outside collaborators.", count)
+
+		if skipped > 0 {
+			log.Warnf("Skipped %d repositories without sufficient permissions to list their outside collaborators.", skipped)
+		}
+
+		_ = org.Locked(func(o *github.Organization) error {
+			o.OutsideCollaborators = &count
+			return nil
+		})
+	}
+
+	f.removeOrganizationJob(org, job)
+
+	return err
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package fetcher
+
+import (
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	scanProjectItemsJobKey = "scan-project-items"
+)
+
+type scanProjectItemsJobMeta struct {
+	cursor string
+	items  []github.ProjectItem
+}
+
+// processScanProjectItemsJob lists all items in a project, one page at a
+// time. Items are collected across pages and only replace the project's
+// current items once the last page has been fetched, so that removed items
+// disappear from the project.
+func (f *Fetcher) processScanProjectItemsJob(project *github.Project, log logrus.FieldLogger, job string, data interface{}) error {
+	meta := data.(scanProjectItemsJobMeta)
+
+	items, title, cursor, err := f.client.ListProjectItems(project, meta.cursor)
+
+	// always delete the job, no matter the outcome; the refresh worker
+	// will schedule a new scan later on
+	f.removeProjectJob(project, job)
+
+	if err != nil {
+		return err
+	}
+
+	items = append(meta.items, items...)
+
+	log.WithField("new-cursor", cursor).Debugf("Fetched %d project items.", len(items))
+
+	// queue the query for the next page
+	if cursor != "" {
+		f.enqueueProjectJob(project, job, scanProjectItemsJobMeta{
+			cursor: cursor,
+			items:  items,
+		})
+
+		return nil
+	}
+
+	project.SetItems(title, items, time.Now())
+
+	return nil
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package fetcher
+
+import (
+	"sort"
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	updateTimelinesJobKey = "update-timelines"
+
+	// maxTimelinesPerJob limits how many timelines are fetched in one go,
+	// so that other jobs are not blocked for too long.
+	maxTimelinesPerJob = 25
+
+	// timelineRetryBackoff is multiplied with the number of consecutive
+	// failures to determine when a failed timeline is retried.
+	timelineRetryBackoff    = 1 * time.Hour
+	maxTimelineRetryBackoff = 24 * time.Hour
+)
+
+type timelineCandidate struct {
+	number      int
+	pullRequest bool
+	timeline    *github.Timeline
+}
+
+// timelineCandidates returns all open issues and PRs that have no timeline
+// yet, plus all items that have been updated since their timeline was fetched
+// (updates). Closed items that have no timeline yet are returned separately
+// (backfills), so that they can be fetched with a lower priority. Items whose
+// last update failed are skipped until their back-off has passed.
+func timelineCandidates(repo *github.Repository) (updates []timelineCandidate, backfills []timelineCandidate) {
+	now := time.Now()
+
+	add := func(candidate timelineCandidate, open bool, updatedAt time.Time) {
+		timeline := candidate.timeline
+
+		switch {
+		case timeline == nil && !open:
+			backfills = append(backfills, candidate)
+		case timeline == nil:
+			updates = append(updates, candidate)
+		case now.Before(timeline.RetryAfter):
+			// back off after failures
+		case updatedAt.After(timeline.FetchedAt):
+			updates = append(updates, candidate)
+		}
+	}
+
+	_ = repo.RLocked(func(r *github.Repository) error {
+		for number, issue := range r.Issues {
+			add(timelineCandidate{number: number, timeline: issue.Timeline}, issue.State == githubv4.IssueStateOpen, issue.UpdatedAt)
+		}
+
+		for number, pr := range r.PullRequests {
+			add(timelineCandidate{number: number, pullRequest: true, timeline: pr.Timeline}, pr.State == githubv4.PullRequestStateOpen, pr.UpdatedAt)
+		}
+
+		return nil
+	})
+
+	// update the most recent items first
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].number > updates[j].number
+	})
+
+	sort.Slice(backfills, func(i, j int) bool {
+		return backfills[i].number > backfills[j].number
+	})
+
+	return updates, backfills
+}
+
+// failedTimeline returns a copy of the timeline (or an empty, never fetched
+// timeline) that records another failed update.
+func failedTimeline(timeline *github.Timeline, now time.Time) *github.Timeline {
+	failed := &github.Timeline{}
+	if timeline != nil {
+		*failed = *timeline
+	}
+
+	failed.Failures++
+
+	backoff := time.Duration(failed.Failures) * timelineRetryBackoff
+	if backoff > maxTimelineRetryBackoff {
+		backoff = maxTimelineRetryBackoff
+	}
+
+	failed.RetryAfter = now.Add(backoff)
+
+	return failed
+}
+
+// processUpdateTimelinesJob incrementally fetches the timelines of changed
+// issues and PRs. If there are more candidates than can be processed at
+// once, the job re-enqueues itself. Closed items without a timeline are
+// only backfilled with the remaining capacity of each job, so they are
+// fetched gradually over multiple refreshes.
+func (f *Fetcher) processUpdateTimelinesJob(repo *github.Repository, log logrus.FieldLogger, job string) error {
+	candidates, backfills := timelineCandidates(repo)
+
+	// always delete the job, no matter the outcome
+	f.removeJob(repo, job)
+
+	more := len(candidates) > maxTimelinesPerJob
+	if more {
+		candidates = candidates[:maxTimelinesPerJob]
+	} else {
+		remaining := maxTimelinesPerJob - len(candidates)
+		if len(backfills) > remaining {
+			backfills = backfills[:remaining]
+		}
+
+		candidates = append(candidates, backfills...)
+	}
+
+	failed := 0
+
+	for _, candidate := range candidates {
+		now := time.Now()
+		events := []github.TimelineEvent{}
+
+		var since *time.Time
+		if candidate.timeline != nil && !candidate.timeline.FetchedAt.IsZero() {
+			since = &candidate.timeline.FetchedAt
+			events = append(events, candidate.timeline.Events...)
+		}
+
+		var timeline *github.Timeline
+
+		newEvents, err := f.client.ListTimelineEvents(repo.Owner, repo.Name, candidate.number, since)
+		if err != nil {
+			log.WithField("number", candidate.number).Warnf("Failed to fetch timeline: %v", err)
+
+			timeline = failedTimeline(candidate.timeline, now)
+			failed++
+		} else {
+			timeline = &github.Timeline{
+				Events:    append(events, newEvents...),
+				FetchedAt: now,
+			}
+		}
+
+		if candidate.pullRequest {
+			repo.SetPullRequestTimeline(candidate.number, timeline)
+		} else {
+			repo.SetIssueTimeline(candidate.number, timeline)
+		}
+	}
+
+	log.Debugf("Updated %d timelines (%d failed).", len(candidates)-failed, failed)
+
+	if more {
+		f.enqueueJob(repo, job, nil)
+	}
+
+	return nil
+}
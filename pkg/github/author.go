@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package github
+
+type AuthorType string
+
+const (
+	// AuthorTypeUser is used for items created by regular users.
+	AuthorTypeUser AuthorType = "user"
+	// AuthorTypeBot is used for items created by bot accounts, either GitHub
+	// Apps or accounts that were configured to be bots.
+	AuthorTypeBot AuthorType = "bot"
+)
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package github
+
+type CommentAuthorType string
+
+const (
+	// CommentAuthorTypeAuthor is used for comments by the author of the issue/PR.
+	CommentAuthorTypeAuthor CommentAuthorType = "author"
+	// CommentAuthorTypeMember is used for comments by repository members, owners
+	// and collaborators.
+	CommentAuthorTypeMember CommentAuthorType = "member"
+	// CommentAuthorTypeBot is used for comments by bot accounts.
+	CommentAuthorTypeBot CommentAuthorType = "bot"
+	// CommentAuthorTypeOther is used for all other users.
+	CommentAuthorTypeOther CommentAuthorType = "other"
+)
+
+// IsHuman returns true for all comments that were not made by a bot.
+func (t CommentAuthorType) IsHuman() bool {
+	return t != CommentAuthorTypeBot
+}
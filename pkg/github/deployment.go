@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+type Deployment struct {
+	ID          string
+	Environment string
+	State       githubv4.DeploymentState
+	CreatedAt   time.Time
+
+	// CommittedAt is the commit date of the deployed commit.
+	CommittedAt *time.Time
+
+	// SucceededAt is set if the latest status of the deployment is a
+	// success and contains the time that status was created.
+	SucceededAt *time.Time
+}
+
+// LeadTime returns the duration from commit to successful deployment,
+// or nil if the deployment did not succeed.
+func (d *Deployment) LeadTime() *time.Duration {
+	if d.CommittedAt == nil || d.SucceededAt == nil {
+		return nil
+	}
+
+	leadTime := d.SucceededAt.Sub(*d.CommittedAt)
+
+	return &leadTime
+}
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+type Discussion struct {
+	Number      int
+	Author      string
+	State       githubv4.DiscussionState
+	Category    string
+	IsAnswered  bool
+	UpvoteCount int
+	Comments    int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	FetchedAt   time.Time
+}
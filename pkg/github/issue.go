@@ -11,13 +11,41 @@ import (
 )
 
 type Issue struct {
-	Number    int
-	Author    string
-	State     githubv4.IssueState
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	FetchedAt time.Time
-	Labels    []string
+	Number      int
+	Author      string
+	State       githubv4.IssueState
+	StateReason githubv4.IssueStateReason
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ClosedAt    *time.Time
+	FetchedAt   time.Time
+	Labels      []string
+	Assignees   []string
+
+	// Milestone is the number of the issue's milestone, 0 if it has none.
+	Milestone int
+
+	// AuthorAssociation is the author's relation to the repository.
+	AuthorAssociation githubv4.CommentAuthorAssociation
+
+	// AuthorType distinguishes between regular users and bots.
+	AuthorType AuthorType
+
+	// Timeline is only fetched if timeline labels are configured and is
+	// carried over when the item is updated.
+	Timeline *Timeline
+
+	// Reactions maps the lowercased reaction content (e.g. "thumbs_up")
+	// to the number of users who reacted that way.
+	Reactions map[string]int
+
+	Comments      int
+	LastCommentAt *time.Time
+	LastCommentBy CommentAuthorType
+
+	// AwaitingResponseSince is set if no human other than the author has
+	// responded to the item yet, or if the author responded last.
+	AwaitingResponseSince *time.Time
 }
 
 func (i *Issue) HasLabel(label string) bool {
@@ -31,3 +59,16 @@ func (i *Issue) HasLabel(label string) bool {
 
 	return false
 }
+
+func (i *Issue) IsAssigned() bool {
+	return len(i.Assignees) > 0
+}
+
+func (i *Issue) TotalReactions() int {
+	total := 0
+	for _, count := range i.Reactions {
+		total += count
+	}
+
+	return total
+}
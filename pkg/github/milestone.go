@@ -23,3 +23,43 @@ type Milestone struct {
 	OpenPullRequests   int
 	ClosedPullRequests int
 }
+
+// MaxBurndownDays is the number of days for which a milestone's burndown
+// is kept.
+const MaxBurndownDays = 90
+
+// Burndown maps a day (YYYY-MM-DD, UTC) to the number of open issues and PRs
+// of a milestone, as last seen on that day.
+type Burndown map[string]int
+
+// Record sets the number of open items for the day of t and removes entries
+// older than MaxBurndownDays.
+func (b Burndown) Record(t time.Time, open int) {
+	t = t.UTC()
+	b[t.Format(time.DateOnly)] = open
+
+	cutoff := t.AddDate(0, 0, -MaxBurndownDays).Format(time.DateOnly)
+	for day := range b {
+		if day < cutoff {
+			delete(b, day)
+		}
+	}
+}
+
+// OpenItems returns the number of open issues and PRs.
+func (m *Milestone) OpenItems() int {
+	return m.OpenIssues + m.OpenPullRequests
+}
+
+// CompletionRatio returns the ratio of closed to all issues and PRs, or 0 if
+// the milestone has no items.
+func (m *Milestone) CompletionRatio() float64 {
+	closed := m.ClosedIssues + m.ClosedPullRequests
+	total := closed + m.OpenItems()
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(closed) / float64(total)
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBurndownRecord(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name     string
+		burndown Burndown
+		t        time.Time
+		open     int
+		expected Burndown
+	}{
+		{
+			name:     "first entry",
+			burndown: Burndown{},
+			t:        now,
+			open:     3,
+			expected: Burndown{"2023-06-01": 3},
+		},
+		{
+			name:     "same day is overwritten",
+			burndown: Burndown{"2023-06-01": 5},
+			t:        now,
+			open:     4,
+			expected: Burndown{"2023-06-01": 4},
+		},
+		{
+			name:     "days are in UTC",
+			burndown: Burndown{},
+			t:        time.Date(2023, 6, 1, 23, 0, 0, 0, time.FixedZone("UTC-2", -2*60*60)),
+			open:     1,
+			expected: Burndown{"2023-06-02": 1},
+		},
+		{
+			name: "old entries are pruned",
+			burndown: Burndown{
+				"2023-03-02": 9,
+				"2023-03-03": 8,
+				"2023-05-31": 2,
+			},
+			t:    now,
+			open: 1,
+			expected: Burndown{
+				"2023-03-03": 8,
+				"2023-05-31": 2,
+				"2023-06-01": 1,
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			testcase.burndown.Record(testcase.t, testcase.open)
+
+			if len(testcase.burndown) != len(testcase.expected) {
+				t.Fatalf("Expected %v, got %v.", testcase.expected, testcase.burndown)
+			}
+
+			for day, open := range testcase.expected {
+				if got, ok := testcase.burndown[day]; !ok || got != open {
+					t.Fatalf("Expected %v, got %v.", testcase.expected, testcase.burndown)
+				}
+			}
+		})
+	}
+}
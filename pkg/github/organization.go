@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"sync"
+	"time"
+)
+
+type Organization struct {
+	Login string
+
+	Members            int
+	PendingInvitations int
+	// Teams maps team slugs to the number of their direct members.
+	Teams     map[string]int
+	FetchedAt *time.Time
+
+	// OutsideCollaborators is nil until it has been successfully fetched,
+	// as this requires admin permissions on the organization's repositories.
+	OutsideCollaborators *int
+
+	lock sync.RWMutex
+}
+
+func NewOrganization(login string) *Organization {
+	return &Organization{
+		Login: login,
+		Teams: map[string]int{},
+		lock:  sync.RWMutex{},
+	}
+}
+
+func (o *Organization) Locked(callback func(*Organization) error) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return callback(o)
+}
+
+func (o *Organization) RLocked(callback func(*Organization) error) error {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	return callback(o)
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+type ProjectItem struct {
+	Type githubv4.ProjectV2ItemType
+	// Repository and Number are empty for draft issues.
+	Repository string
+	Number     int
+	Status     string
+	Iteration  string
+}
+
+// Project is a GitHub Projects (v2) board owned by an organization or user.
+type Project struct {
+	Owner  string
+	Number int
+
+	// StatusField and IterationField are the names of the custom fields
+	// whose values are recorded for every item.
+	StatusField    string
+	IterationField string
+
+	Title     string
+	Items     []ProjectItem
+	FetchedAt *time.Time
+
+	lock sync.RWMutex
+}
+
+func NewProject(owner string, number int, statusField string, iterationField string) *Project {
+	return &Project{
+		Owner:          owner,
+		Number:         number,
+		StatusField:    statusField,
+		IterationField: iterationField,
+		Items:          []ProjectItem{},
+		lock:           sync.RWMutex{},
+	}
+}
+
+func (p *Project) FullName() string {
+	return fmt.Sprintf("%s/%d", p.Owner, p.Number)
+}
+
+func (p *Project) SetItems(title string, items []ProjectItem, fetchedAt time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.Title = title
+	p.Items = items
+	p.FetchedAt = &fetchedAt
+}
+
+func (p *Project) RLocked(callback func(*Project) error) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return callback(p)
+}
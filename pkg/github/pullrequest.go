@@ -15,15 +15,50 @@ type BuildContext struct {
 	State githubv4.StatusState
 }
 
+// IssueReference points to an issue, possibly in another repository.
+type IssueReference struct {
+	// Repository is the full name (owner/name) of the issue's repository.
+	Repository string
+	Number     int
+}
+
 type PullRequest struct {
 	Number    int
 	Author    string
 	State     githubv4.PullRequestState
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	ClosedAt  *time.Time
+	MergedAt  *time.Time
+	MergedBy  string
 	FetchedAt time.Time
 	Labels    []string
+	Assignees []string
 	Contexts  []BuildContext
+
+	// Milestone is the number of the PR's milestone, 0 if it has none.
+	Milestone int
+
+	// AuthorAssociation is the author's relation to the repository.
+	AuthorAssociation githubv4.CommentAuthorAssociation
+
+	// AuthorType distinguishes between regular users and bots.
+	AuthorType AuthorType
+
+	// LinkedIssues are the issues that will be closed when the PR is merged.
+	LinkedIssues []IssueReference
+
+	// Timeline is only fetched if timeline labels are configured and is
+	// carried over when the item is updated.
+	Timeline *Timeline
+
+	Comments      int
+	LastCommentAt *time.Time
+	LastCommentBy CommentAuthorType
+
+	// AwaitingResponseSince is set if no human other than the author has
+	// responded to the item yet, or if the author responded last.
+	AwaitingResponseSince *time.Time
 }
 
 func (p *PullRequest) HasLabel(label string) bool {
@@ -38,6 +73,10 @@ func (p *PullRequest) HasLabel(label string) bool {
 	return false
 }
 
+func (p *PullRequest) IsAssigned() bool {
+	return len(p.Assignees) > 0
+}
+
 func (p *PullRequest) Context(name string) *BuildContext {
 	for i, ctx := range p.Contexts {
 		if ctx.Name == name {
@@ -18,6 +18,8 @@ type Repository struct {
 	PullRequests   map[int]PullRequest
 	Issues         map[int]Issue
 	Milestones     map[int]Milestone
+	Burndowns      map[int]Burndown
+	Discussions    map[int]Discussion
 	Labels         []string
 	DiskUsageBytes int
 	Forks          int
@@ -31,6 +33,14 @@ type Repository struct {
 	IsMirror       bool
 	IsTemplate     bool
 	Languages      map[string]int
+	Topics         []string
+	License        string
+	DefaultBranch  string
+	Visibility     string
+	Language       string
+	HasHomepage    bool
+	Traffic        *Traffic
+	Deployments    []Deployment
 	FetchedAt      *time.Time
 
 	lock sync.RWMutex
@@ -43,8 +53,11 @@ func NewRepository(owner string, name string) *Repository {
 		PullRequests: map[int]PullRequest{},
 		Issues:       map[int]Issue{},
 		Milestones:   map[int]Milestone{},
+		Burndowns:    map[int]Burndown{},
+		Discussions:  map[int]Discussion{},
 		Labels:       []string{},
 		Languages:    map[string]int{},
+		Topics:       []string{},
 		lock:         sync.RWMutex{},
 	}
 }
@@ -60,11 +73,44 @@ func (d *Repository) SetLabels(Labels []string) {
 	d.Labels = Labels
 }
 
+// SetIssueTimeline sets the timeline of an issue, if it still exists.
+func (d *Repository) SetIssueTimeline(number int, timeline *Timeline) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if issue, ok := d.Issues[number]; ok {
+		issue.Timeline = timeline
+		d.Issues[number] = issue
+	}
+}
+
+// SetPullRequestTimeline sets the timeline of a pull request, if it still exists.
+func (d *Repository) SetPullRequestTimeline(number int, timeline *Timeline) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if pr, ok := d.PullRequests[number]; ok {
+		pr.Timeline = timeline
+		d.PullRequests[number] = pr
+	}
+}
+
+func (d *Repository) SetDeployments(deployments []Deployment) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.Deployments = deployments
+}
+
 func (d *Repository) AddPullRequests(prs []PullRequest) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
 	for _, pr := range prs {
+		if existing, ok := d.PullRequests[pr.Number]; ok && pr.Timeline == nil {
+			pr.Timeline = existing.Timeline
+		}
+
 		d.PullRequests[pr.Number] = pr
 	}
 }
@@ -110,6 +156,10 @@ func (d *Repository) AddIssues(issues []Issue) {
 	defer d.lock.Unlock()
 
 	for _, issue := range issues {
+		if existing, ok := d.Issues[issue.Number]; ok && issue.Timeline == nil {
+			issue.Timeline = existing.Timeline
+		}
+
 		d.Issues[issue.Number] = issue
 	}
 }
@@ -156,7 +206,52 @@ func (d *Repository) AddMilestones(milestones []Milestone) {
 
 	for _, milestone := range milestones {
 		d.Milestones[milestone.Number] = milestone
+
+		// keep track of the open items of open milestones, closed
+		// milestones do not need a burndown anymore
+		if milestone.State == githubv4.MilestoneStateOpen {
+			burndown, ok := d.Burndowns[milestone.Number]
+			if !ok {
+				burndown = Burndown{}
+				d.Burndowns[milestone.Number] = burndown
+			}
+
+			burndown.Record(milestone.FetchedAt, milestone.OpenItems())
+		} else {
+			delete(d.Burndowns, milestone.Number)
+		}
+	}
+}
+
+// SetBurndowns replaces the milestone burndowns with a copy of the given
+// ones, e.g. when restoring them from a previous run.
+func (d *Repository) SetBurndowns(burndowns map[int]Burndown) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.Burndowns = map[int]Burndown{}
+	for number, burndown := range burndowns {
+		d.Burndowns[number] = Burndown{}
+		for day, open := range burndown {
+			d.Burndowns[number][day] = open
+		}
+	}
+}
+
+// GetBurndowns returns a copy of the milestone burndowns.
+func (d *Repository) GetBurndowns() map[int]Burndown {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	burndowns := map[int]Burndown{}
+	for number, burndown := range d.Burndowns {
+		burndowns[number] = Burndown{}
+		for day, open := range burndown {
+			burndowns[number][day] = open
+		}
 	}
+
+	return burndowns
 }
 
 func (d *Repository) DeleteMilestones(numbers []int) {
@@ -165,6 +260,7 @@ func (d *Repository) DeleteMilestones(numbers []int) {
 
 	for _, number := range numbers {
 		delete(d.Milestones, number)
+		delete(d.Burndowns, number)
 	}
 }
 
@@ -195,6 +291,51 @@ func (d *Repository) GetMilestones(states ...githubv4.MilestoneState) []Mileston
 	return numbers
 }
 
+func (d *Repository) AddDiscussions(discussions []Discussion) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for _, discussion := range discussions {
+		d.Discussions[discussion.Number] = discussion
+	}
+}
+
+func (d *Repository) DeleteDiscussions(numbers []int) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for _, number := range numbers {
+		delete(d.Discussions, number)
+	}
+}
+
+func (d *Repository) GetDiscussions(states ...githubv4.DiscussionState) []Discussion {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	numbers := []Discussion{}
+	for _, discussion := range d.Discussions {
+		include := false
+
+		if len(states) == 0 {
+			include = true
+		} else {
+			for _, state := range states {
+				if discussion.State == state {
+					include = true
+					break
+				}
+			}
+		}
+
+		if include {
+			numbers = append(numbers, discussion)
+		}
+	}
+
+	return numbers
+}
+
 func (d *Repository) Locked(callback func(*Repository) error) error {
 	d.lock.Lock()
 	defer d.lock.Unlock()
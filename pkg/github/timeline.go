@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+type TimelineEventType string
+
+const (
+	TimelineEventLabeled   TimelineEventType = "labeled"
+	TimelineEventUnlabeled TimelineEventType = "unlabeled"
+	TimelineEventClosed    TimelineEventType = "closed"
+	TimelineEventReopened  TimelineEventType = "reopened"
+)
+
+type TimelineEvent struct {
+	Type      TimelineEventType
+	Label     string
+	CreatedAt time.Time
+}
+
+// Timeline contains the label and state changes of an issue or pull request.
+// It is fetched incrementally, FetchedAt is used as the starting point for
+// the next update (a zero FetchedAt means the timeline was never fetched).
+type Timeline struct {
+	Events    []TimelineEvent
+	FetchedAt time.Time
+
+	// Failures is the number of consecutive failed updates; no update is
+	// attempted before RetryAfter.
+	Failures   int
+	RetryAfter time.Time
+}
+
+// LabelInterval is a time span during which an open item had a label.
+// End is nil if the item still has the label.
+type LabelInterval struct {
+	Label string
+	Start time.Time
+	End   *time.Time
+}
+
+// LabelIntervals computes the intervals for each label from the timeline
+// events. Closing an item ends all of its intervals, reopening it starts new
+// intervals for all labels it had when it was closed.
+func (t *Timeline) LabelIntervals() []LabelInterval {
+	events := make([]TimelineEvent, len(t.Events))
+	copy(events, t.Events)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	intervals := []LabelInterval{}
+	active := map[string]time.Time{}
+	suspended := map[string]struct{}{}
+	closed := false
+
+	end := func(label string, at time.Time) {
+		if start, ok := active[label]; ok {
+			end := at
+			intervals = append(intervals, LabelInterval{Label: label, Start: start, End: &end})
+			delete(active, label)
+		}
+	}
+
+	for _, event := range events {
+		label := strings.ToLower(event.Label)
+
+		switch event.Type {
+		case TimelineEventLabeled:
+			if closed {
+				suspended[label] = struct{}{}
+			} else if _, ok := active[label]; !ok {
+				active[label] = event.CreatedAt
+			}
+
+		case TimelineEventUnlabeled:
+			delete(suspended, label)
+			end(label, event.CreatedAt)
+
+		case TimelineEventClosed:
+			for label := range active {
+				suspended[label] = struct{}{}
+				end(label, event.CreatedAt)
+			}
+			closed = true
+
+		case TimelineEventReopened:
+			for label := range suspended {
+				active[label] = event.CreatedAt
+			}
+			suspended = map[string]struct{}{}
+			closed = false
+		}
+	}
+
+	for label, start := range active {
+		intervals = append(intervals, LabelInterval{Label: label, Start: start})
+	}
+
+	return intervals
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestTimelineLabelIntervals(t *testing.T) {
+	base := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time {
+		return base.Add(time.Duration(hours) * time.Hour)
+	}
+
+	testcases := []struct {
+		name     string
+		events   []TimelineEvent
+		expected []string
+	}{
+		{
+			name:     "no events",
+			expected: []string{},
+		},
+		{
+			name: "label still active",
+			events: []TimelineEvent{
+				{Type: TimelineEventLabeled, Label: "bug", CreatedAt: at(1)},
+			},
+			expected: []string{"bug 1-"},
+		},
+		{
+			name: "labels are lowercased and events sorted",
+			events: []TimelineEvent{
+				{Type: TimelineEventUnlabeled, Label: "Bug", CreatedAt: at(3)},
+				{Type: TimelineEventLabeled, Label: "BUG", CreatedAt: at(1)},
+			},
+			expected: []string{"bug 1-3"},
+		},
+		{
+			name: "relabelling an active label does not restart it",
+			events: []TimelineEvent{
+				{Type: TimelineEventLabeled, Label: "bug", CreatedAt: at(1)},
+				{Type: TimelineEventLabeled, Label: "bug", CreatedAt: at(2)},
+				{Type: TimelineEventUnlabeled, Label: "bug", CreatedAt: at(4)},
+			},
+			expected: []string{"bug 1-4"},
+		},
+		{
+			name: "closing ends and reopening restarts intervals",
+			events: []TimelineEvent{
+				{Type: TimelineEventLabeled, Label: "bug", CreatedAt: at(1)},
+				{Type: TimelineEventClosed, CreatedAt: at(2)},
+				{Type: TimelineEventReopened, CreatedAt: at(5)},
+			},
+			expected: []string{"bug 1-2", "bug 5-"},
+		},
+		{
+			name: "labels removed while closed are not restarted",
+			events: []TimelineEvent{
+				{Type: TimelineEventLabeled, Label: "bug", CreatedAt: at(1)},
+				{Type: TimelineEventClosed, CreatedAt: at(2)},
+				{Type: TimelineEventUnlabeled, Label: "bug", CreatedAt: at(3)},
+				{Type: TimelineEventLabeled, Label: "wontfix", CreatedAt: at(4)},
+				{Type: TimelineEventReopened, CreatedAt: at(5)},
+			},
+			expected: []string{"bug 1-2", "wontfix 5-"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			timeline := Timeline{Events: testcase.events}
+
+			result := []string{}
+			for _, interval := range timeline.LabelIntervals() {
+				end := ""
+				if interval.End != nil {
+					end = fmt.Sprintf("%d", int(interval.End.Sub(base).Hours()))
+				}
+
+				result = append(result, fmt.Sprintf("%s %d-%s", interval.Label, int(interval.Start.Sub(base).Hours()), end))
+			}
+			sort.Strings(result)
+
+			if fmt.Sprint(result) != fmt.Sprint(testcase.expected) {
+				t.Fatalf("Expected intervals %v, got %v.", testcase.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package github
+
+import "time"
+
+// Traffic contains the repository traffic of the last 14 days.
+type Traffic struct {
+	Views          int
+	UniqueVisitors int
+	Clones         int
+	UniqueCloners  int
+	Referrers      map[string]int
+	DailyViews     []TrafficDay
+	DailyClones    []TrafficDay
+	FetchedAt      time.Time
+}
+
+// TrafficDay contains the views or clones of a single day.
+type TrafficDay struct {
+	// Date is formatted as YYYY-MM-DD (UTC).
+	Date    string
+	Count   int
+	Uniques int
+}
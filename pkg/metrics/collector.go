@@ -4,12 +4,17 @@
 package metrics
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"go.xrstf.de/github_exporter/pkg/client"
 	"go.xrstf.de/github_exporter/pkg/fetcher"
 	"go.xrstf.de/github_exporter/pkg/github"
+	"go.xrstf.de/github_exporter/pkg/policy"
 	"go.xrstf.de/github_exporter/pkg/prow"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -32,22 +37,126 @@ var (
 		string(githubv4.MilestoneStateOpen),
 		string(githubv4.MilestoneStateClosed),
 	}
+
+	AllAuthorAssociations = []githubv4.CommentAuthorAssociation{
+		githubv4.CommentAuthorAssociationOwner,
+		githubv4.CommentAuthorAssociationMember,
+		githubv4.CommentAuthorAssociationCollaborator,
+		githubv4.CommentAuthorAssociationContributor,
+		githubv4.CommentAuthorAssociationFirstTimeContributor,
+		githubv4.CommentAuthorAssociationFirstTimer,
+		githubv4.CommentAuthorAssociationMannequin,
+		githubv4.CommentAuthorAssociationNone,
+	}
+
+	// ContributorWindows are the time windows (by PR creation date) for
+	// which contributor statistics are reported.
+	ContributorWindows = map[string]time.Duration{
+		"30d":  30 * 24 * time.Hour,
+		"90d":  90 * 24 * time.Hour,
+		"365d": 365 * 24 * time.Hour,
+	}
+)
+
+type Options struct {
+	// AwaitingResponseDays are the thresholds (in days) for which the number
+	// of open items that are waiting for a human response is reported.
+	AwaitingResponseDays []int
+
+	// TopReactedIssues is the number of open issues per repository for
+	// which individual reaction metrics are reported.
+	TopReactedIssues int
+
+	// TimelineLabels are the labels for which time-in-label metrics are
+	// reported, based on the issue/PR timelines.
+	TimelineLabels []string
+
+	// Policies are evaluated for all issues and PRs.
+	Policies []policy.Policy
+
+	// AgeBuckets are the histogram buckets (in seconds) for the age of
+	// open items; if empty, no age histograms are reported.
+	AgeBuckets []float64
+
+	// ItemSeries controls for which issues/PRs per-item series are reported;
+	// aggregated metrics always include all items.
+	ItemSeries ItemSeriesMode
+
+	// ItemSeriesWindow is the time window for ItemSeriesRecent.
+	ItemSeriesWindow time.Duration
+
+	// ExcludeBots excludes items created by bots from all aggregated
+	// metrics; per-item series are still reported.
+	ExcludeBots bool
+}
+
+// thumbsUpReaction is the (lowercased) reaction content used to rank the
+// top reacted issues.
+const thumbsUpReaction = "thumbs_up"
+
+type ItemSeriesMode string
+
+const (
+	// ItemSeriesAll reports per-item series for all items.
+	ItemSeriesAll ItemSeriesMode = "all"
+	// ItemSeriesOpen reports per-item series only for open items.
+	ItemSeriesOpen ItemSeriesMode = "open"
+	// ItemSeriesRecent reports per-item series for open items and items
+	// that were closed within the ItemSeriesWindow.
+	ItemSeriesRecent ItemSeriesMode = "recent"
 )
 
 type Collector struct {
-	repos   map[string]*github.Repository
-	fetcher *fetcher.Fetcher
-	client  *client.Client
+	lock     sync.RWMutex
+	repos    map[string]*github.Repository
+	projects map[string]*github.Project
+	orgs     map[string]*github.Organization
+	fetcher  *fetcher.Fetcher
+	client   *client.Client
+	options  Options
 }
 
-func NewCollector(repos map[string]*github.Repository, fetcher *fetcher.Fetcher, client *client.Client) *Collector {
+func NewCollector(
+	repos map[string]*github.Repository,
+	projects map[string]*github.Project,
+	orgs map[string]*github.Organization,
+	fetcher *fetcher.Fetcher,
+	client *client.Client,
+	options Options,
+) *Collector {
+	// copy the map, so that repositories can be added/removed at runtime
+	// without affecting the caller
+	repositories := map[string]*github.Repository{}
+	for fullName, repo := range repos {
+		repositories[fullName] = repo
+	}
+
 	return &Collector{
-		repos:   repos,
-		fetcher: fetcher,
-		client:  client,
+		repos:    repositories,
+		projects: projects,
+		orgs:     orgs,
+		fetcher:  fetcher,
+		client:   client,
+		options:  options,
 	}
 }
 
+// AddRepository starts reporting metrics for the given repository.
+func (mc *Collector) AddRepository(repo *github.Repository) {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+
+	mc.repos[repo.FullName()] = repo
+}
+
+// RemoveRepository stops reporting metrics for the given repository.
+func (mc *Collector) RemoveRepository(repo *github.Repository) {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+
+	delete(mc.repos, repo.FullName())
+}
+
 func (mc *Collector) Describe(ch chan<- *prometheus.Desc) {
 	prometheus.DescribeByCollect(mc, ch)
 }
@@ -55,6 +164,10 @@ func (mc *Collector) Describe(ch chan<- *prometheus.Desc) {
 func (mc *Collector) Collect(ch chan<- prometheus.Metric) {
 	requestCounts := mc.client.GetRequestCounts()
 	costs := mc.client.GetTotalCosts()
+	labelFollowUps := mc.client.GetLabelFollowUps()
+
+	mc.lock.RLock()
+	defer mc.lock.RUnlock()
 
 	for _, repo := range mc.repos {
 		// do not publish metrics for repos for which we have not even fetched
@@ -69,11 +182,37 @@ func (mc *Collector) Collect(ch chan<- prometheus.Metric) {
 			return mc.collectRepository(ch, r)
 		})
 
-		ch <- constMetric(githubRequestsTotal, prometheus.CounterValue, float64(requestCounts[fullName]), fullName)
-		ch <- constMetric(githubCostsTotal, prometheus.CounterValue, float64(costs[fullName]), fullName)
+		target := client.RequestTarget{Kind: client.RequestKindRepository, Name: fullName}
+
+		ch <- constMetric(githubRequestsTotal, prometheus.CounterValue, float64(requestCounts[target]), fullName)
+		ch <- constMetric(githubCostsTotal, prometheus.CounterValue, float64(costs[target]), fullName)
+		ch <- constMetric(githubLabelFollowUpItems, prometheus.GaugeValue, float64(labelFollowUps[fullName]), fullName)
+	}
+
+	for _, project := range mc.projects {
+		_ = project.RLocked(func(p *github.Project) error {
+			return mc.collectProject(ch, p)
+		})
+	}
+
+	for _, org := range mc.orgs {
+		_ = org.RLocked(func(o *github.Organization) error {
+			return mc.collectOrganization(ch, o)
+		})
+	}
+
+	// requests for projects and owners are not tied to a repository
+	for target, count := range requestCounts {
+		if target.Kind == client.RequestKindRepository {
+			continue
+		}
+
+		ch <- constMetric(githubTargetRequestsTotal, prometheus.CounterValue, float64(count), target.Kind, target.Name)
+		ch <- constMetric(githubTargetCostsTotal, prometheus.CounterValue, float64(costs[target]), target.Kind, target.Name)
 	}
 
 	ch <- constMetric(githubPointsRemaining, prometheus.GaugeValue, float64(mc.client.GetRemainingPoints()))
+	ch <- constMetric(githubRESTRequestsRemaining, prometheus.GaugeValue, float64(mc.client.GetRemainingRESTRequests()))
 }
 
 func (mc *Collector) collectRepository(ch chan<- prometheus.Metric, repo *github.Repository) error {
@@ -93,9 +232,62 @@ func (mc *Collector) collectRepository(ch chan<- prometheus.Metric, repo *github
 		return err
 	}
 
+	if err := mc.collectRepoDiscussions(ch, repo); err != nil {
+		return err
+	}
+
+	if err := mc.collectRepoDeployments(ch, repo); err != nil {
+		return err
+	}
+
+	if err := mc.collectRepoPolicies(ch, repo); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// itemSink forwards per-item series, unless the item is excluded by the
+// ItemSeries option, in which case the series are only counted.
+type itemSink struct {
+	ch         chan<- prometheus.Metric
+	include    bool
+	suppressed *int
+}
+
+func (mc *Collector) newItemSink(ch chan<- prometheus.Metric, open bool, closedAt *time.Time, suppressed *int) itemSink {
+	include := true
+
+	if !open {
+		switch mc.options.ItemSeries {
+		case ItemSeriesOpen:
+			include = false
+		case ItemSeriesRecent:
+			include = closedAt != nil && time.Since(*closedAt) <= mc.options.ItemSeriesWindow
+		}
+	}
+
+	return itemSink{
+		ch:         ch,
+		include:    include,
+		suppressed: suppressed,
+	}
+}
+
+func (s itemSink) Send(metric prometheus.Metric) {
+	if s.include {
+		s.ch <- metric
+	} else {
+		*s.suppressed++
+	}
+}
+
+// includeInAggregates returns false for items that must not be counted in
+// aggregated metrics.
+func (mc *Collector) includeInAggregates(authorType github.AuthorType) bool {
+	return !mc.options.ExcludeBots || authorType != github.AuthorTypeBot
+}
+
 func boolVal(b bool) float64 {
 	if b {
 		return 1
@@ -104,6 +296,14 @@ func boolVal(b bool) float64 {
 	return 0
 }
 
+func timestampVal(t *time.Time) float64 {
+	if t == nil {
+		return 0
+	}
+
+	return float64(t.Unix())
+}
+
 func (mc *Collector) collectRepoInfo(ch chan<- prometheus.Metric, repo *github.Repository) error {
 	repoName := repo.FullName()
 
@@ -119,40 +319,228 @@ func (mc *Collector) collectRepoInfo(ch chan<- prometheus.Metric, repo *github.R
 	ch <- constMetric(repositoryMirror, prometheus.GaugeValue, boolVal(repo.IsMirror), repoName)
 	ch <- constMetric(repositoryTemplate, prometheus.GaugeValue, boolVal(repo.IsTemplate), repoName)
 
+	topics := append([]string{}, repo.Topics...)
+	sort.Strings(topics)
+
+	ch <- constMetric(
+		repositoryInfo, prometheus.GaugeValue, 1,
+		repoName,
+		strings.Join(topics, ","),
+		repo.License,
+		repo.DefaultBranch,
+		repo.Visibility,
+		repo.Language,
+		fmt.Sprintf("%v", repo.HasHomepage),
+	)
+
 	for language, size := range repo.Languages {
 		ch <- constMetric(repositoryLanguageSize, prometheus.GaugeValue, float64(size), repoName, language)
 	}
 
+	if traffic := repo.Traffic; traffic != nil {
+		ch <- constMetric(repositoryTrafficViews, prometheus.GaugeValue, float64(traffic.Views), repoName)
+		ch <- constMetric(repositoryTrafficUniqueVisitors, prometheus.GaugeValue, float64(traffic.UniqueVisitors), repoName)
+		ch <- constMetric(repositoryTrafficClones, prometheus.GaugeValue, float64(traffic.Clones), repoName)
+		ch <- constMetric(repositoryTrafficUniqueCloners, prometheus.GaugeValue, float64(traffic.UniqueCloners), repoName)
+		ch <- constMetric(repositoryTrafficFetchedAt, prometheus.GaugeValue, float64(traffic.FetchedAt.Unix()), repoName)
+
+		for referrer, views := range traffic.Referrers {
+			ch <- constMetric(repositoryTrafficReferrerViews, prometheus.GaugeValue, float64(views), repoName, referrer)
+		}
+
+		views := lastCompleteTrafficDay(traffic.DailyViews, traffic.FetchedAt)
+		ch <- constMetric(repositoryTrafficDailyViews, prometheus.GaugeValue, float64(views.Count), repoName)
+		ch <- constMetric(repositoryTrafficDailyUniqueVisitors, prometheus.GaugeValue, float64(views.Uniques), repoName)
+
+		clones := lastCompleteTrafficDay(traffic.DailyClones, traffic.FetchedAt)
+		ch <- constMetric(repositoryTrafficDailyClones, prometheus.GaugeValue, float64(clones.Count), repoName)
+		ch <- constMetric(repositoryTrafficDailyUniqueCloners, prometheus.GaugeValue, float64(clones.Uniques), repoName)
+	}
+
 	return nil
 }
 
+// lastCompleteTrafficDay returns the traffic of the day before the traffic
+// was fetched, as the current day is still incomplete. GitHub omits days
+// without traffic, so a missing day is reported as zero.
+func lastCompleteTrafficDay(days []github.TrafficDay, fetchedAt time.Time) github.TrafficDay {
+	yesterday := fetchedAt.UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+
+	for _, day := range days {
+		if day.Date == yesterday {
+			return day
+		}
+	}
+
+	return github.TrafficDay{Date: yesterday}
+}
+
+// collectRepoPolicies reports the number of violations per policy; the
+// per-item policy_violation_info series are reported alongside the other
+// per-item series of issues and PRs.
+func (mc *Collector) collectRepoPolicies(ch chan<- prometheus.Metric, repo *github.Repository) error {
+	repoName := repo.FullName()
+	now := time.Now()
+
+	for i := range mc.options.Policies {
+		p := &mc.options.Policies[i]
+		violations := 0
+
+		for _, issue := range repo.Issues {
+			if mc.includeInAggregates(issue.AuthorType) && p.ViolatedByIssue(&issue, now) {
+				violations++
+			}
+		}
+
+		for _, pr := range repo.PullRequests {
+			if mc.includeInAggregates(pr.AuthorType) && p.ViolatedByPullRequest(&pr, now) {
+				violations++
+			}
+		}
+
+		ch <- constMetric(policyViolations, prometheus.GaugeValue, float64(violations), repoName, p.Name)
+	}
+
+	return nil
+}
+
+func (mc *Collector) collectRepoDeployments(ch chan<- prometheus.Metric, repo *github.Repository) error {
+	repoName := repo.FullName()
+
+	counts := map[string]map[string]int{}
+	lastSuccess := map[string]time.Time{}
+	leadTimes := map[string][]float64{}
+
+	for _, deployment := range repo.Deployments {
+		env := deployment.Environment
+
+		if _, ok := counts[env]; !ok {
+			counts[env] = map[string]int{}
+		}
+		counts[env][strings.ToLower(string(deployment.State))]++
+
+		if deployment.SucceededAt != nil && deployment.SucceededAt.After(lastSuccess[env]) {
+			lastSuccess[env] = *deployment.SucceededAt
+		}
+
+		if leadTime := deployment.LeadTime(); leadTime != nil {
+			leadTimes[env] = append(leadTimes[env], leadTime.Seconds())
+		}
+	}
+
+	for env, states := range counts {
+		for state, count := range states {
+			ch <- constMetric(deploymentCount, prometheus.GaugeValue, float64(count), repoName, env, state)
+		}
+	}
+
+	for env, timestamp := range lastSuccess {
+		ch <- constMetric(deploymentLastSuccessAt, prometheus.GaugeValue, float64(timestamp.Unix()), repoName, env)
+	}
+
+	for env, values := range leadTimes {
+		sort.Float64s(values)
+
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+
+		quantiles := map[float64]float64{
+			0.5: quantile(values, 0.5),
+			0.9: quantile(values, 0.9),
+		}
+
+		ch <- prometheus.MustNewConstSummary(deploymentLeadTime, uint64(len(values)), sum, quantiles, repoName, env)
+	}
+
+	return nil
+}
+
+// quantile returns the q-quantile of the given sorted, non-empty values.
+func quantile(sorted []float64, q float64) float64 {
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
 func (mc *Collector) collectRepoPullRequests(ch chan<- prometheus.Metric, repo *github.Repository) error {
 	totals := newStateLabelMap(repo, AllPullRequestStates)
+	assignees := map[string]int{}
+	awaiting := newAwaitingResponseCounter(mc.options.AwaitingResponseDays)
+	contributors := newContributorCounter()
+	labelDurations := newLabelDurationCounter(mc.options.TimelineLabels)
+	ages := newAgeCounter(mc.options.AgeBuckets)
+	suppressed := 0
 	repoName := repo.FullName()
+	now := time.Now()
 
 	for number, pr := range repo.PullRequests {
 		num := strconv.Itoa(number)
+		sink := mc.newItemSink(ch, pr.State == githubv4.PullRequestStateOpen, pr.ClosedAt, &suppressed)
+		aggregate := mc.includeInAggregates(pr.AuthorType)
 
-		for _, label := range pr.Labels {
-			totals[string(pr.State)][label]++
+		if aggregate {
+			contributors.Add(&pr)
+		}
+
+		labelDurations.Add(pr.Timeline, pr.State == githubv4.PullRequestStateOpen, aggregate, func(label string, seconds float64) {
+			sink.Send(constMetric(pullRequestLabelActive, prometheus.GaugeValue, seconds, repoName, num, label))
+		})
+
+		if aggregate {
+			for _, label := range pr.Labels {
+				totals[string(pr.State)][label]++
+			}
+
+			if pr.State == githubv4.PullRequestStateOpen {
+				for _, assignee := range pr.Assignees {
+					assignees[assignee]++
+				}
+
+				awaiting.Add(pr.AwaitingResponseSince)
+				ages.Add(pr.Labels, pr.CreatedAt, pr.UpdatedAt)
+			}
 		}
 
 		infoLabels := []string{
 			repoName,
 			num,
 			pr.Author,
+			string(pr.AuthorType),
 			strings.ToLower(string(pr.State)),
+			fmt.Sprintf("%v", pr.IsAssigned()),
+			milestoneLabel(pr.Milestone),
 		}
 		infoLabels = append(infoLabels, prow.PullRequestLabels(&pr)...)
 
-		ch <- constMetric(pullRequestInfo, prometheus.GaugeValue, 1, infoLabels...)
-		ch <- constMetric(pullRequestCreatedAt, prometheus.GaugeValue, float64(pr.CreatedAt.Unix()), repoName, num)
-		ch <- constMetric(pullRequestUpdatedAt, prometheus.GaugeValue, float64(pr.UpdatedAt.Unix()), repoName, num)
-		ch <- constMetric(pullRequestFetchedAt, prometheus.GaugeValue, float64(pr.FetchedAt.Unix()), repoName, num)
+		sink.Send(constMetric(pullRequestInfo, prometheus.GaugeValue, 1, infoLabels...))
+		sink.Send(constMetric(pullRequestCreatedAt, prometheus.GaugeValue, float64(pr.CreatedAt.Unix()), repoName, num))
+		sink.Send(constMetric(pullRequestUpdatedAt, prometheus.GaugeValue, float64(pr.UpdatedAt.Unix()), repoName, num))
+		sink.Send(constMetric(pullRequestClosedAt, prometheus.GaugeValue, timestampVal(pr.ClosedAt), repoName, num))
+		sink.Send(constMetric(pullRequestMergedAt, prometheus.GaugeValue, timestampVal(pr.MergedAt), repoName, num, pr.MergedBy))
+		sink.Send(constMetric(pullRequestFetchedAt, prometheus.GaugeValue, float64(pr.FetchedAt.Unix()), repoName, num))
+		sink.Send(constMetric(pullRequestComments, prometheus.GaugeValue, float64(pr.Comments), repoName, num))
+		sink.Send(constMetric(pullRequestLastCommentAt, prometheus.GaugeValue, timestampVal(pr.LastCommentAt), repoName, num, string(pr.LastCommentBy)))
+		sink.Send(constMetric(pullRequestLinkedIssues, prometheus.GaugeValue, float64(len(linkedIssues(repo, &pr))), repoName, num))
+
+		for i := range mc.options.Policies {
+			if p := &mc.options.Policies[i]; p.ViolatedByPullRequest(&pr, now) {
+				sink.Send(constMetric(policyViolationInfo, prometheus.GaugeValue, 1, repoName, p.Name, policy.KindPullRequest, num))
+			}
+		}
 	}
 
 	totals.ToMetrics(ch, repo, pullRequestLabelCount)
 
+	for assignee, count := range assignees {
+		ch <- constMetric(pullRequestAssigneeOpenCount, prometheus.GaugeValue, float64(count), repoName, assignee)
+	}
+
+	awaiting.ToMetrics(ch, repo, pullRequestAwaitingResponseCount)
+	contributors.ToMetrics(ch, repo)
+	labelDurations.ToMetrics(ch, repo, pullRequestLabelDuration)
+	ages.ToMetrics(ch, repo, pullRequestOpenAge, pullRequestOpenIdle)
+
+	ch <- constMetric(suppressedItemSeries, prometheus.GaugeValue, float64(suppressed), repoName, "pullrequest")
 	ch <- constMetric(pullRequestQueueSize, prometheus.GaugeValue, float64(mc.fetcher.PriorityPullRequestQueueSize(repo)), repoName, "priority")
 	ch <- constMetric(pullRequestQueueSize, prometheus.GaugeValue, float64(mc.fetcher.RegularPullRequestQueueSize(repo)), repoName, "regular")
 
@@ -161,31 +549,109 @@ func (mc *Collector) collectRepoPullRequests(ch chan<- prometheus.Metric, repo *
 
 func (mc *Collector) collectRepoIssues(ch chan<- prometheus.Metric, repo *github.Repository) error {
 	totals := newStateLabelMap(repo, AllIssueStates)
+	reactions := map[string]map[string]int{}
+	assignees := map[string]int{}
+	awaiting := newAwaitingResponseCounter(mc.options.AwaitingResponseDays)
+	labelDurations := newLabelDurationCounter(mc.options.TimelineLabels)
+	ages := newAgeCounter(mc.options.AgeBuckets)
+	suppressed := 0
 	repoName := repo.FullName()
+	now := time.Now()
+
+	// determine which issues are linked to open PRs
+	hasLinkedPR := map[int]bool{}
+	for _, pr := range repo.PullRequests {
+		if pr.State == githubv4.PullRequestStateOpen {
+			for _, number := range linkedIssues(repo, &pr) {
+				hasLinkedPR[number] = true
+			}
+		}
+	}
 
 	for number, issue := range repo.Issues {
 		num := strconv.Itoa(number)
+		sink := mc.newItemSink(ch, issue.State == githubv4.IssueStateOpen, issue.ClosedAt, &suppressed)
+		aggregate := mc.includeInAggregates(issue.AuthorType)
+
+		labelDurations.Add(issue.Timeline, issue.State == githubv4.IssueStateOpen, aggregate, func(label string, seconds float64) {
+			sink.Send(constMetric(issueLabelActive, prometheus.GaugeValue, seconds, repoName, num, label))
+		})
 
-		for _, label := range issue.Labels {
-			totals[string(issue.State)][label]++
+		if aggregate {
+			for _, label := range issue.Labels {
+				totals[string(issue.State)][label]++
+			}
+
+			for content, count := range issue.Reactions {
+				if _, ok := reactions[string(issue.State)]; !ok {
+					reactions[string(issue.State)] = map[string]int{}
+				}
+
+				reactions[string(issue.State)][content] += count
+			}
+
+			if issue.State == githubv4.IssueStateOpen {
+				for _, assignee := range issue.Assignees {
+					assignees[assignee]++
+				}
+
+				awaiting.Add(issue.AwaitingResponseSince)
+				ages.Add(issue.Labels, issue.CreatedAt, issue.UpdatedAt)
+			}
 		}
 
 		infoLabels := []string{
 			repoName,
 			num,
 			issue.Author,
+			string(issue.AuthorType),
 			strings.ToLower(string(issue.State)),
+			strings.ToLower(string(issue.StateReason)),
+			fmt.Sprintf("%v", issue.IsAssigned()),
+			fmt.Sprintf("%v", hasLinkedPR[number]),
+			milestoneLabel(issue.Milestone),
 		}
 		infoLabels = append(infoLabels, prow.IssueLabels(&issue)...)
 
-		ch <- constMetric(issueInfo, prometheus.GaugeValue, 1, infoLabels...)
-		ch <- constMetric(issueCreatedAt, prometheus.GaugeValue, float64(issue.CreatedAt.Unix()), repoName, num)
-		ch <- constMetric(issueUpdatedAt, prometheus.GaugeValue, float64(issue.UpdatedAt.Unix()), repoName, num)
-		ch <- constMetric(issueFetchedAt, prometheus.GaugeValue, float64(issue.FetchedAt.Unix()), repoName, num)
+		sink.Send(constMetric(issueInfo, prometheus.GaugeValue, 1, infoLabels...))
+		sink.Send(constMetric(issueCreatedAt, prometheus.GaugeValue, float64(issue.CreatedAt.Unix()), repoName, num))
+		sink.Send(constMetric(issueUpdatedAt, prometheus.GaugeValue, float64(issue.UpdatedAt.Unix()), repoName, num))
+		sink.Send(constMetric(issueClosedAt, prometheus.GaugeValue, timestampVal(issue.ClosedAt), repoName, num))
+		sink.Send(constMetric(issueFetchedAt, prometheus.GaugeValue, float64(issue.FetchedAt.Unix()), repoName, num))
+		sink.Send(constMetric(issueComments, prometheus.GaugeValue, float64(issue.Comments), repoName, num))
+		sink.Send(constMetric(issueLastCommentAt, prometheus.GaugeValue, timestampVal(issue.LastCommentAt), repoName, num, string(issue.LastCommentBy)))
+
+		for i := range mc.options.Policies {
+			if p := &mc.options.Policies[i]; p.ViolatedByIssue(&issue, now) {
+				sink.Send(constMetric(policyViolationInfo, prometheus.GaugeValue, 1, repoName, p.Name, policy.KindIssue, num))
+			}
+		}
 	}
 
 	totals.ToMetrics(ch, repo, issueLabelCount)
 
+	for assignee, count := range assignees {
+		ch <- constMetric(issueAssigneeOpenCount, prometheus.GaugeValue, float64(count), repoName, assignee)
+	}
+
+	awaiting.ToMetrics(ch, repo, issueAwaitingResponseCount)
+	for state, counts := range reactions {
+		for content, count := range counts {
+			ch <- constMetric(issueReactionCount, prometheus.GaugeValue, float64(count), repoName, content, strings.ToLower(state))
+		}
+	}
+	labelDurations.ToMetrics(ch, repo, issueLabelDuration)
+	ages.ToMetrics(ch, repo, issueOpenAge, issueOpenIdle)
+
+	for _, issue := range mc.topReactedIssues(repo) {
+		num := strconv.Itoa(issue.Number)
+
+		for content, count := range issue.Reactions {
+			ch <- constMetric(issueReactions, prometheus.GaugeValue, float64(count), repoName, num, content)
+		}
+	}
+
+	ch <- constMetric(suppressedItemSeries, prometheus.GaugeValue, float64(suppressed), repoName, "issue")
 	ch <- constMetric(issueQueueSize, prometheus.GaugeValue, float64(mc.fetcher.PriorityIssueQueueSize(repo)), repoName, "priority")
 	ch <- constMetric(issueQueueSize, prometheus.GaugeValue, float64(mc.fetcher.RegularIssueQueueSize(repo)), repoName, "regular")
 
@@ -196,6 +662,8 @@ func (mc *Collector) collectRepoMilestones(ch chan<- prometheus.Metric, repo *gi
 	repoName := repo.FullName()
 	openState := strings.ToLower(string(githubv4.MilestoneStateOpen))
 	closedState := strings.ToLower(string(githubv4.MilestoneStateClosed))
+	openLabels := mc.openMilestoneLabelCounts(repo)
+	now := time.Now()
 
 	for number, milestone := range repo.Milestones {
 		num := strconv.Itoa(number)
@@ -220,6 +688,23 @@ func (mc *Collector) collectRepoMilestones(ch chan<- prometheus.Metric, repo *gi
 		ch <- constMetric(milestoneIssues, prometheus.GaugeValue, float64(milestone.ClosedIssues), repoName, num, "issue", closedState)
 		ch <- constMetric(milestoneIssues, prometheus.GaugeValue, float64(milestone.OpenPullRequests), repoName, num, "pullrequest", openState)
 		ch <- constMetric(milestoneIssues, prometheus.GaugeValue, float64(milestone.ClosedPullRequests), repoName, num, "pullrequest", closedState)
+		ch <- constMetric(milestoneCompletionRatio, prometheus.GaugeValue, milestone.CompletionRatio(), repoName, num)
+		ch <- constMetric(milestoneRemainingItems, prometheus.GaugeValue, float64(milestone.OpenItems()), repoName, num)
+		ch <- constMetric(milestoneClosedItems, prometheus.GaugeValue, float64(milestone.ClosedIssues+milestone.ClosedPullRequests), repoName, num)
+
+		if milestone.DueOn != nil {
+			dueIn := milestone.DueOn.Sub(now).Hours() / 24
+			overdue := milestone.State == githubv4.MilestoneStateOpen && dueIn < 0
+
+			ch <- constMetric(milestoneDueInDays, prometheus.GaugeValue, dueIn, repoName, num)
+			ch <- constMetric(milestoneOverdue, prometheus.GaugeValue, boolVal(overdue), repoName, num)
+		}
+
+		for kind, labels := range openLabels[number] {
+			for label, count := range labels {
+				ch <- constMetric(milestoneOpenLabelCount, prometheus.GaugeValue, float64(count), repoName, num, kind, label)
+			}
+		}
 	}
 
 	ch <- constMetric(milestoneQueueSize, prometheus.GaugeValue, float64(mc.fetcher.PriorityMilestoneQueueSize(repo)), repoName, "priority")
@@ -228,6 +713,113 @@ func (mc *Collector) collectRepoMilestones(ch chan<- prometheus.Metric, repo *gi
 	return nil
 }
 
+func (mc *Collector) collectRepoDiscussions(ch chan<- prometheus.Metric, repo *github.Repository) error {
+	repoName := repo.FullName()
+
+	for number, discussion := range repo.Discussions {
+		num := strconv.Itoa(number)
+
+		ch <- constMetric(discussionInfo, prometheus.GaugeValue, 1, repoName, num, discussion.Author, strings.ToLower(string(discussion.State)), discussion.Category, fmt.Sprintf("%v", discussion.IsAnswered))
+		ch <- constMetric(discussionUpvotes, prometheus.GaugeValue, float64(discussion.UpvoteCount), repoName, num)
+		ch <- constMetric(discussionComments, prometheus.GaugeValue, float64(discussion.Comments), repoName, num)
+		ch <- constMetric(discussionCreatedAt, prometheus.GaugeValue, float64(discussion.CreatedAt.Unix()), repoName, num)
+		ch <- constMetric(discussionUpdatedAt, prometheus.GaugeValue, float64(discussion.UpdatedAt.Unix()), repoName, num)
+		ch <- constMetric(discussionFetchedAt, prometheus.GaugeValue, float64(discussion.FetchedAt.Unix()), repoName, num)
+	}
+
+	ch <- constMetric(discussionQueueSize, prometheus.GaugeValue, float64(mc.fetcher.PriorityDiscussionQueueSize(repo)), repoName, "priority")
+	ch <- constMetric(discussionQueueSize, prometheus.GaugeValue, float64(mc.fetcher.RegularDiscussionQueueSize(repo)), repoName, "regular")
+
+	return nil
+}
+
+func (mc *Collector) collectProject(ch chan<- prometheus.Metric, project *github.Project) error {
+	// do not publish metrics for projects that have not been completely fetched yet
+	if project.FetchedAt == nil {
+		return nil
+	}
+
+	projectName := project.FullName()
+	statusCounts := map[string]int{}
+
+	for _, item := range project.Items {
+		statusCounts[item.Status]++
+
+		// draft issues and redacted items cannot be identified
+		if item.Repository == "" {
+			continue
+		}
+
+		ch <- constMetric(projectItemInfo, prometheus.GaugeValue, 1, projectName, item.Repository, strconv.Itoa(item.Number), item.Status, item.Iteration)
+	}
+
+	for status, count := range statusCounts {
+		ch <- constMetric(projectItems, prometheus.GaugeValue, float64(count), projectName, status)
+	}
+
+	ch <- constMetric(projectFetchedAt, prometheus.GaugeValue, float64(project.FetchedAt.Unix()), projectName)
+
+	return nil
+}
+
+func (mc *Collector) collectOrganization(ch chan<- prometheus.Metric, org *github.Organization) error {
+	// the outside collaborators are fetched independently from the rest
+	if org.OutsideCollaborators != nil {
+		ch <- constMetric(organizationOutsideCollaborators, prometheus.GaugeValue, float64(*org.OutsideCollaborators), org.Login)
+	}
+
+	if org.FetchedAt == nil {
+		return nil
+	}
+
+	ch <- constMetric(organizationMembers, prometheus.GaugeValue, float64(org.Members), org.Login)
+	ch <- constMetric(organizationPendingInvitations, prometheus.GaugeValue, float64(org.PendingInvitations), org.Login)
+	ch <- constMetric(organizationTeams, prometheus.GaugeValue, float64(len(org.Teams)), org.Login)
+	ch <- constMetric(organizationFetchedAt, prometheus.GaugeValue, float64(org.FetchedAt.Unix()), org.Login)
+
+	for team, members := range org.Teams {
+		ch <- constMetric(organizationTeamMembers, prometheus.GaugeValue, float64(members), org.Login, team)
+	}
+
+	return nil
+}
+
+// topReactedIssues returns the open issues with the most thumbs up
+// reactions; ties are broken by the total number of reactions.
+func (mc *Collector) topReactedIssues(repo *github.Repository) []github.Issue {
+	limit := mc.options.TopReactedIssues
+	if limit <= 0 {
+		return nil
+	}
+
+	candidates := []github.Issue{}
+	for _, issue := range repo.Issues {
+		if issue.State == githubv4.IssueStateOpen && len(issue.Reactions) > 0 && mc.includeInAggregates(issue.AuthorType) {
+			candidates = append(candidates, issue)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i].Reactions[thumbsUpReaction], candidates[j].Reactions[thumbsUpReaction]
+		if a != b {
+			return a > b
+		}
+
+		a, b = candidates[i].TotalReactions(), candidates[j].TotalReactions()
+		if a != b {
+			return a > b
+		}
+
+		return candidates[i].Number > candidates[j].Number
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates
+}
+
 // constMetric just helps reducing code noise
 func constMetric(desc *prometheus.Desc, valueType prometheus.ValueType, value float64, labelValues ...string) prometheus.Metric {
 	return prometheus.MustNewConstMetric(desc, valueType, value, labelValues...)
@@ -258,3 +850,324 @@ func (m stateLabelMap) ToMetrics(ch chan<- prometheus.Metric, repo *github.Repos
 		}
 	}
 }
+
+func milestoneLabel(number int) string {
+	if number == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(number)
+}
+
+// openMilestoneLabelCounts counts the labels of all open issues and PRs per
+// milestone number and kind. Only labels that occur are included, to not
+// multiply the number of series by the number of repository labels.
+func (mc *Collector) openMilestoneLabelCounts(repo *github.Repository) map[int]map[string]map[string]int {
+	counts := map[int]map[string]map[string]int{}
+
+	add := func(milestone int, kind string, labels []string) {
+		if milestone == 0 {
+			return
+		}
+
+		if _, ok := counts[milestone]; !ok {
+			counts[milestone] = map[string]map[string]int{}
+		}
+
+		if _, ok := counts[milestone][kind]; !ok {
+			counts[milestone][kind] = map[string]int{}
+		}
+
+		for _, label := range labels {
+			counts[milestone][kind][label]++
+		}
+	}
+
+	for _, issue := range repo.Issues {
+		if issue.State == githubv4.IssueStateOpen && mc.includeInAggregates(issue.AuthorType) {
+			add(issue.Milestone, "issue", issue.Labels)
+		}
+	}
+
+	for _, pr := range repo.PullRequests {
+		if pr.State == githubv4.PullRequestStateOpen && mc.includeInAggregates(pr.AuthorType) {
+			add(pr.Milestone, "pullrequest", pr.Labels)
+		}
+	}
+
+	return counts
+}
+
+// linkedIssues returns the numbers of the issues a PR will close that belong
+// to the same repository and are known to the exporter. References to issues
+// in other repositories are ignored, as their numbers could otherwise clash
+// with local issues.
+func linkedIssues(repo *github.Repository, pr *github.PullRequest) []int {
+	fullName := repo.FullName()
+	numbers := []int{}
+
+	for _, ref := range pr.LinkedIssues {
+		if !strings.EqualFold(ref.Repository, fullName) {
+			continue
+		}
+
+		if _, ok := repo.Issues[ref.Number]; ok {
+			numbers = append(numbers, ref.Number)
+		}
+	}
+
+	return numbers
+}
+
+// awaitingResponseCounter counts how many items have been waiting for a
+// human response for at least a given number of days.
+type awaitingResponseCounter struct {
+	now    time.Time
+	counts map[int]int
+}
+
+func newAwaitingResponseCounter(days []int) awaitingResponseCounter {
+	counter := awaitingResponseCounter{
+		now:    time.Now(),
+		counts: map[int]int{},
+	}
+
+	for _, d := range days {
+		counter.counts[d] = 0
+	}
+
+	return counter
+}
+
+func (c awaitingResponseCounter) Add(since *time.Time) {
+	if since == nil {
+		return
+	}
+
+	waiting := c.now.Sub(*since)
+
+	for days := range c.counts {
+		if waiting >= time.Duration(days)*24*time.Hour {
+			c.counts[days]++
+		}
+	}
+}
+
+func (c awaitingResponseCounter) ToMetrics(ch chan<- prometheus.Metric, repo *github.Repository, metric *prometheus.Desc) {
+	repoName := repo.FullName()
+
+	for days, count := range c.counts {
+		ch <- prometheus.MustNewConstMetric(metric, prometheus.GaugeValue, float64(count), repoName, strconv.Itoa(days))
+	}
+}
+
+// contributorCounter counts the distinct authors and their pull requests
+// per author association and time window.
+type contributorCounter struct {
+	now          time.Time
+	authors      map[string]map[githubv4.CommentAuthorAssociation]map[string]struct{}
+	pullRequests map[string]map[githubv4.CommentAuthorAssociation]int
+}
+
+func newContributorCounter() contributorCounter {
+	counter := contributorCounter{
+		now:          time.Now(),
+		authors:      map[string]map[githubv4.CommentAuthorAssociation]map[string]struct{}{},
+		pullRequests: map[string]map[githubv4.CommentAuthorAssociation]int{},
+	}
+
+	for window := range ContributorWindows {
+		counter.authors[window] = map[githubv4.CommentAuthorAssociation]map[string]struct{}{}
+		counter.pullRequests[window] = map[githubv4.CommentAuthorAssociation]int{}
+
+		for _, association := range AllAuthorAssociations {
+			counter.authors[window][association] = map[string]struct{}{}
+		}
+	}
+
+	return counter
+}
+
+func (c contributorCounter) Add(pr *github.PullRequest) {
+	if pr.AuthorAssociation == "" {
+		return
+	}
+
+	age := c.now.Sub(pr.CreatedAt)
+
+	for window, duration := range ContributorWindows {
+		if age > duration {
+			continue
+		}
+
+		authors, ok := c.authors[window][pr.AuthorAssociation]
+		if !ok {
+			authors = map[string]struct{}{}
+			c.authors[window][pr.AuthorAssociation] = authors
+		}
+
+		// PRs of bots and deleted users still count, but not as a distinct
+		// contributor; bots have no user ID, so without this check they would
+		// only be counted when -realnames or -pseudonymize is used
+		if pr.Author != "" && pr.AuthorType != github.AuthorTypeBot {
+			authors[pr.Author] = struct{}{}
+		}
+
+		c.pullRequests[window][pr.AuthorAssociation]++
+	}
+}
+
+func (c contributorCounter) ToMetrics(ch chan<- prometheus.Metric, repo *github.Repository) {
+	repoName := repo.FullName()
+
+	for window, associations := range c.authors {
+		for association, authors := range associations {
+			label := strings.ToLower(string(association))
+
+			ch <- prometheus.MustNewConstMetric(repositoryContributors, prometheus.GaugeValue, float64(len(authors)), repoName, label, window)
+			ch <- prometheus.MustNewConstMetric(repositoryContributorPullRequests, prometheus.GaugeValue, float64(c.pullRequests[window][association]), repoName, label, window)
+		}
+	}
+}
+
+// LabelDurationBuckets are the histogram buckets (in seconds) for the
+// time-in-label metrics.
+var LabelDurationBuckets = []float64{
+	(1 * time.Hour).Seconds(),
+	(6 * time.Hour).Seconds(),
+	(24 * time.Hour).Seconds(),
+	(3 * 24 * time.Hour).Seconds(),
+	(7 * 24 * time.Hour).Seconds(),
+	(14 * 24 * time.Hour).Seconds(),
+	(30 * 24 * time.Hour).Seconds(),
+	(90 * 24 * time.Hour).Seconds(),
+}
+
+// constHistogram collects observations for a const histogram metric.
+type constHistogram struct {
+	buckets map[float64]uint64
+	count   uint64
+	sum     float64
+}
+
+func newConstHistogram(buckets []float64) *constHistogram {
+	h := &constHistogram{
+		buckets: map[float64]uint64{},
+	}
+
+	for _, bucket := range buckets {
+		h.buckets[bucket] = 0
+	}
+
+	return h
+}
+
+func (h *constHistogram) Observe(value float64) {
+	h.count++
+	h.sum += value
+
+	for bucket := range h.buckets {
+		if value <= bucket {
+			h.buckets[bucket]++
+		}
+	}
+}
+
+func (h *constHistogram) ToMetric(metric *prometheus.Desc, labels ...string) prometheus.Metric {
+	return prometheus.MustNewConstHistogram(metric, h.count, h.sum, h.buckets, labels...)
+}
+
+// labelDurationCounter builds histograms of how long items had one of the
+// configured labels, based on their timelines.
+type labelDurationCounter struct {
+	now        time.Time
+	histograms map[string]*constHistogram
+}
+
+func newLabelDurationCounter(labels []string) labelDurationCounter {
+	counter := labelDurationCounter{
+		now:        time.Now(),
+		histograms: map[string]*constHistogram{},
+	}
+
+	for _, label := range labels {
+		counter.histograms[strings.ToLower(label)] = newConstHistogram(LabelDurationBuckets)
+	}
+
+	return counter
+}
+
+// Add records all finished label intervals of the timeline, unless observe is
+// false. For open items, active is called for each configured label the item
+// currently has, with the number of seconds since the label was applied.
+func (c labelDurationCounter) Add(timeline *github.Timeline, open bool, observe bool, active func(label string, seconds float64)) {
+	if timeline == nil || len(c.histograms) == 0 {
+		return
+	}
+
+	for _, interval := range timeline.LabelIntervals() {
+		histogram, ok := c.histograms[interval.Label]
+		if !ok {
+			continue
+		}
+
+		if interval.End != nil {
+			if observe {
+				histogram.Observe(interval.End.Sub(interval.Start).Seconds())
+			}
+		} else if open {
+			active(interval.Label, c.now.Sub(interval.Start).Seconds())
+		}
+	}
+}
+
+func (c labelDurationCounter) ToMetrics(ch chan<- prometheus.Metric, repo *github.Repository, metric *prometheus.Desc) {
+	repoName := repo.FullName()
+
+	for label, histogram := range c.histograms {
+		ch <- histogram.ToMetric(metric, repoName, label)
+	}
+}
+
+// ageCounter builds histograms of the age and the time since the last update
+// of open items, grouped by their kind and priority.
+type ageCounter struct {
+	now     time.Time
+	buckets []float64
+	age     map[[2]string]*constHistogram
+	idle    map[[2]string]*constHistogram
+}
+
+func newAgeCounter(buckets []float64) ageCounter {
+	return ageCounter{
+		now:     time.Now(),
+		buckets: buckets,
+		age:     map[[2]string]*constHistogram{},
+		idle:    map[[2]string]*constHistogram{},
+	}
+}
+
+func (c ageCounter) Add(labels []string, createdAt time.Time, updatedAt time.Time) {
+	if len(c.buckets) == 0 {
+		return
+	}
+
+	key := [2]string{prow.Kind(labels), prow.Priority(labels)}
+
+	if _, ok := c.age[key]; !ok {
+		c.age[key] = newConstHistogram(c.buckets)
+		c.idle[key] = newConstHistogram(c.buckets)
+	}
+
+	c.age[key].Observe(c.now.Sub(createdAt).Seconds())
+	c.idle[key].Observe(c.now.Sub(updatedAt).Seconds())
+}
+
+func (c ageCounter) ToMetrics(ch chan<- prometheus.Metric, repo *github.Repository, ageMetric *prometheus.Desc, idleMetric *prometheus.Desc) {
+	repoName := repo.FullName()
+
+	for key, histogram := range c.age {
+		ch <- histogram.ToMetric(ageMetric, repoName, key[0], key[1])
+		ch <- c.idle[key].ToMetric(idleMetric, repoName, key[0], key[1])
+	}
+}
@@ -90,6 +90,13 @@ var (
 		nil,
 	)
 
+	repositoryInfo = prometheus.NewDesc(
+		"github_exporter_repo_info",
+		"Various metadata about the repository; topics are sorted and comma-separated",
+		[]string{"repo", "topics", "license", "default_branch", "visibility", "language", "has_homepage"},
+		nil,
+	)
+
 	repositoryLanguageSize = prometheus.NewDesc(
 		"github_exporter_repo_language_size_bytes",
 		"Number of bytes in the repository detected as using a given language",
@@ -97,6 +104,131 @@ var (
 		nil,
 	)
 
+	repositoryTrafficViews = prometheus.NewDesc(
+		"github_exporter_repo_traffic_views",
+		"Number of page views of the repository in the last 14 days",
+		[]string{"repo"},
+		nil,
+	)
+
+	repositoryTrafficUniqueVisitors = prometheus.NewDesc(
+		"github_exporter_repo_traffic_unique_visitors",
+		"Number of unique visitors of the repository in the last 14 days",
+		[]string{"repo"},
+		nil,
+	)
+
+	repositoryTrafficClones = prometheus.NewDesc(
+		"github_exporter_repo_traffic_clones",
+		"Number of clones of the repository in the last 14 days",
+		[]string{"repo"},
+		nil,
+	)
+
+	repositoryTrafficUniqueCloners = prometheus.NewDesc(
+		"github_exporter_repo_traffic_unique_cloners",
+		"Number of unique cloners of the repository in the last 14 days",
+		[]string{"repo"},
+		nil,
+	)
+
+	repositoryTrafficReferrerViews = prometheus.NewDesc(
+		"github_exporter_repo_traffic_referrer_views",
+		"Number of views from the top referring sites in the last 14 days",
+		[]string{"repo", "referrer"},
+		nil,
+	)
+
+	repositoryTrafficDailyViews = prometheus.NewDesc(
+		"github_exporter_repo_traffic_daily_views",
+		"Number of page views of the repository on the last complete day (UTC)",
+		[]string{"repo"},
+		nil,
+	)
+
+	repositoryTrafficDailyUniqueVisitors = prometheus.NewDesc(
+		"github_exporter_repo_traffic_daily_unique_visitors",
+		"Number of unique visitors of the repository on the last complete day (UTC)",
+		[]string{"repo"},
+		nil,
+	)
+
+	repositoryTrafficDailyClones = prometheus.NewDesc(
+		"github_exporter_repo_traffic_daily_clones",
+		"Number of clones of the repository on the last complete day (UTC)",
+		[]string{"repo"},
+		nil,
+	)
+
+	repositoryTrafficDailyUniqueCloners = prometheus.NewDesc(
+		"github_exporter_repo_traffic_daily_unique_cloners",
+		"Number of unique cloners of the repository on the last complete day (UTC)",
+		[]string{"repo"},
+		nil,
+	)
+
+	repositoryTrafficFetchedAt = prometheus.NewDesc(
+		"github_exporter_repo_traffic_fetched_at",
+		"UNIX timestamp when the traffic statistics were last fetched",
+		[]string{"repo"},
+		nil,
+	)
+
+	repositoryContributors = prometheus.NewDesc(
+		"github_exporter_repo_contributors",
+		"Number of distinct pull request authors (excluding bots and deleted users) by their association to the repository, for PRs created within the time window",
+		[]string{"repo", "association", "window"},
+		nil,
+	)
+
+	repositoryContributorPullRequests = prometheus.NewDesc(
+		"github_exporter_repo_contributor_pull_requests",
+		"Number of pull requests by the author's association to the repository, for PRs created within the time window",
+		[]string{"repo", "association", "window"},
+		nil,
+	)
+
+	//////////////////////////////////////////////
+	// policies
+
+	policyViolations = prometheus.NewDesc(
+		"github_exporter_policy_violations",
+		"Number of issues and Pull Requests that violate a policy",
+		[]string{"repo", "policy"},
+		nil,
+	)
+
+	policyViolationInfo = prometheus.NewDesc(
+		"github_exporter_policy_violation_info",
+		"Issues and Pull Requests violating a policy with the static value 1",
+		[]string{"repo", "policy", "kind", "number"},
+		nil,
+	)
+
+	//////////////////////////////////////////////
+	// deployments
+
+	deploymentCount = prometheus.NewDesc(
+		"github_exporter_deployments",
+		"Number of deployments created within the deployment window",
+		[]string{"repo", "environment", "state"},
+		nil,
+	)
+
+	deploymentLastSuccessAt = prometheus.NewDesc(
+		"github_exporter_deployment_last_success_at",
+		"UNIX timestamp of the last successful deployment within the deployment window",
+		[]string{"repo", "environment"},
+		nil,
+	)
+
+	deploymentLeadTime = prometheus.NewDesc(
+		"github_exporter_deployment_lead_time_seconds",
+		"Time from commit to successful deployment for deployments within the deployment window",
+		[]string{"repo", "environment"},
+		nil,
+	)
+
 	//////////////////////////////////////////////
 	// pull requests
 
@@ -123,6 +255,20 @@ var (
 		nil,
 	)
 
+	pullRequestClosedAt = prometheus.NewDesc(
+		"github_exporter_pr_closed_at",
+		"UNIX timestamp of a Pull Request's close time (0 if the PR is open)",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	pullRequestMergedAt = prometheus.NewDesc(
+		"github_exporter_pr_merged_at",
+		"UNIX timestamp of a Pull Request's merge time (0 if the PR has not been merged)",
+		[]string{"repo", "number", "merged_by"},
+		nil,
+	)
+
 	pullRequestFetchedAt = prometheus.NewDesc(
 		"github_exporter_pr_fetched_at",
 		"UNIX timestamp of a Pull Request's last fetch time (when it was retrieved from the API)",
@@ -130,6 +276,69 @@ var (
 		nil,
 	)
 
+	pullRequestAssigneeOpenCount = prometheus.NewDesc(
+		"github_exporter_pr_assignee_open_count",
+		"Number of open Pull Requests assigned to a given user",
+		[]string{"repo", "assignee"},
+		nil,
+	)
+
+	pullRequestComments = prometheus.NewDesc(
+		"github_exporter_pr_comments",
+		"Total number of comments on a Pull Request",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	pullRequestLastCommentAt = prometheus.NewDesc(
+		"github_exporter_pr_last_comment_at",
+		"UNIX timestamp of the last comment on a Pull Request (0 if there are no comments)",
+		[]string{"repo", "number", "author_type"},
+		nil,
+	)
+
+	pullRequestOpenAge = prometheus.NewDesc(
+		"github_exporter_pr_open_age_seconds",
+		"Time since open Pull Requests were created, grouped by their kind and priority labels",
+		[]string{"repo", "kind", "priority"},
+		nil,
+	)
+
+	pullRequestOpenIdle = prometheus.NewDesc(
+		"github_exporter_pr_open_idle_seconds",
+		"Time since open Pull Requests were last updated, grouped by their kind and priority labels",
+		[]string{"repo", "kind", "priority"},
+		nil,
+	)
+
+	pullRequestLinkedIssues = prometheus.NewDesc(
+		"github_exporter_pr_linked_issues",
+		"Number of known issues of the same repository that will be closed when the Pull Request is merged",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	pullRequestLabelDuration = prometheus.NewDesc(
+		"github_exporter_pr_label_duration_seconds",
+		"Time Pull Requests had a label before it was removed or the Pull Request was closed",
+		[]string{"repo", "label"},
+		nil,
+	)
+
+	pullRequestLabelActive = prometheus.NewDesc(
+		"github_exporter_pr_label_active_seconds",
+		"Time since a label was applied to an open Pull Request",
+		[]string{"repo", "number", "label"},
+		nil,
+	)
+
+	pullRequestAwaitingResponseCount = prometheus.NewDesc(
+		"github_exporter_pr_awaiting_response_count",
+		"Number of open Pull Requests that have not received a human response for at least the given number of days",
+		[]string{"repo", "days"},
+		nil,
+	)
+
 	pullRequestQueueSize = prometheus.NewDesc(
 		"github_exporter_pr_queue_size",
 		"Number of pull requests currently queued for an update",
@@ -163,6 +372,13 @@ var (
 		nil,
 	)
 
+	issueClosedAt = prometheus.NewDesc(
+		"github_exporter_issue_closed_at",
+		"UNIX timestamp of an Issue's close time (0 if the issue is open)",
+		[]string{"repo", "number"},
+		nil,
+	)
+
 	issueFetchedAt = prometheus.NewDesc(
 		"github_exporter_issue_fetched_at",
 		"UNIX timestamp of an Issue's last fetch time (when it was retrieved from the API)",
@@ -170,6 +386,76 @@ var (
 		nil,
 	)
 
+	issueAssigneeOpenCount = prometheus.NewDesc(
+		"github_exporter_issue_assignee_open_count",
+		"Number of open issues assigned to a given user",
+		[]string{"repo", "assignee"},
+		nil,
+	)
+
+	issueComments = prometheus.NewDesc(
+		"github_exporter_issue_comments",
+		"Total number of comments on an issue",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	issueLastCommentAt = prometheus.NewDesc(
+		"github_exporter_issue_last_comment_at",
+		"UNIX timestamp of the last comment on an issue (0 if there are no comments)",
+		[]string{"repo", "number", "author_type"},
+		nil,
+	)
+
+	issueOpenAge = prometheus.NewDesc(
+		"github_exporter_issue_open_age_seconds",
+		"Time since open issues were created, grouped by their kind and priority labels",
+		[]string{"repo", "kind", "priority"},
+		nil,
+	)
+
+	issueOpenIdle = prometheus.NewDesc(
+		"github_exporter_issue_open_idle_seconds",
+		"Time since open issues were last updated, grouped by their kind and priority labels",
+		[]string{"repo", "kind", "priority"},
+		nil,
+	)
+
+	issueLabelDuration = prometheus.NewDesc(
+		"github_exporter_issue_label_duration_seconds",
+		"Time issues had a label before it was removed or the issue was closed",
+		[]string{"repo", "label"},
+		nil,
+	)
+
+	issueLabelActive = prometheus.NewDesc(
+		"github_exporter_issue_label_active_seconds",
+		"Time since a label was applied to an open issue",
+		[]string{"repo", "number", "label"},
+		nil,
+	)
+
+	issueAwaitingResponseCount = prometheus.NewDesc(
+		"github_exporter_issue_awaiting_response_count",
+		"Number of open issues that have not received a human response for at least the given number of days",
+		[]string{"repo", "days"},
+		nil,
+	)
+
+	issueReactions = prometheus.NewDesc(
+		"github_exporter_issue_reactions",
+		"Number of reactions of a given kind on one of the most reacted-to open issues",
+		[]string{"repo", "number", "content"},
+		nil,
+	)
+
+	issueReactionCount = prometheus.NewDesc(
+		"github_exporter_issue_reaction_count",
+		"Total number of reactions of a given kind across all issues",
+		[]string{"repo", "content", "state"},
+		nil,
+	)
+
 	issueQueueSize = prometheus.NewDesc(
 		"github_exporter_issue_queue_size",
 		"Number of issues currently queued for an update",
@@ -194,6 +480,48 @@ var (
 		nil,
 	)
 
+	milestoneCompletionRatio = prometheus.NewDesc(
+		"github_exporter_milestone_completion_ratio",
+		"Ratio of closed to all issues and Pull Requests belonging to a milestone (0 if it has none)",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	milestoneDueInDays = prometheus.NewDesc(
+		"github_exporter_milestone_due_in_days",
+		"Number of days until the milestone is due, negative if the due date has passed",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	milestoneOverdue = prometheus.NewDesc(
+		"github_exporter_milestone_overdue",
+		"1 if the milestone is open and its due date has passed, 0 otherwise",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	milestoneRemainingItems = prometheus.NewDesc(
+		"github_exporter_milestone_remaining_items",
+		"Number of open issues and Pull Requests of a milestone",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	milestoneClosedItems = prometheus.NewDesc(
+		"github_exporter_milestone_closed_items",
+		"Number of closed issues and Pull Requests of a milestone",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	milestoneOpenLabelCount = prometheus.NewDesc(
+		"github_exporter_milestone_open_label_count",
+		"Number of open issues/PRs belonging to a milestone that have a given label",
+		[]string{"repo", "number", "kind", "label"},
+		nil,
+	)
+
 	milestoneCreatedAt = prometheus.NewDesc(
 		"github_exporter_milestone_created_at",
 		"UNIX timestamp of a Milestone's creation time",
@@ -236,6 +564,127 @@ var (
 		nil,
 	)
 
+	//////////////////////////////////////////////
+	// discussions
+
+	discussionInfo = prometheus.NewDesc(
+		"github_exporter_discussion_info",
+		"Various discussion related meta information with the static value 1",
+		[]string{"repo", "number", "author", "state", "category", "answered"},
+		nil,
+	)
+
+	discussionUpvotes = prometheus.NewDesc(
+		"github_exporter_discussion_upvotes",
+		"Number of upvotes of a Discussion",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	discussionComments = prometheus.NewDesc(
+		"github_exporter_discussion_comments",
+		"Total number of comments on a Discussion",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	discussionCreatedAt = prometheus.NewDesc(
+		"github_exporter_discussion_created_at",
+		"UNIX timestamp of a Discussion's creation time",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	discussionUpdatedAt = prometheus.NewDesc(
+		"github_exporter_discussion_updated_at",
+		"UNIX timestamp of a Discussion's last update time",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	discussionFetchedAt = prometheus.NewDesc(
+		"github_exporter_discussion_fetched_at",
+		"UNIX timestamp of a Discussion's last fetch time (when it was retrieved from the API)",
+		[]string{"repo", "number"},
+		nil,
+	)
+
+	discussionQueueSize = prometheus.NewDesc(
+		"github_exporter_discussion_queue_size",
+		"Number of discussions currently queued for an update",
+		[]string{"repo", "queue"},
+		nil,
+	)
+
+	//////////////////////////////////////////////
+	// projects
+
+	projectItemInfo = prometheus.NewDesc(
+		"github_exporter_project_item_info",
+		"Various information about issues and Pull Requests in a project with the static value 1",
+		[]string{"project", "repo", "number", "status", "iteration"},
+		nil,
+	)
+
+	projectItems = prometheus.NewDesc(
+		"github_exporter_project_items",
+		"Number of items in a project, grouped by status (includes draft issues)",
+		[]string{"project", "status"},
+		nil,
+	)
+
+	projectFetchedAt = prometheus.NewDesc(
+		"github_exporter_project_fetched_at",
+		"UNIX timestamp of when the project's items were last completely retrieved from the API",
+		[]string{"project"},
+		nil,
+	)
+
+	//////////////////////////////////////////////
+	// organizations
+
+	organizationMembers = prometheus.NewDesc(
+		"github_exporter_org_members",
+		"Number of members of an organization",
+		[]string{"org"},
+		nil,
+	)
+
+	organizationPendingInvitations = prometheus.NewDesc(
+		"github_exporter_org_pending_invitations",
+		"Number of users who have been invited to an organization, but have not yet accepted",
+		[]string{"org"},
+		nil,
+	)
+
+	organizationOutsideCollaborators = prometheus.NewDesc(
+		"github_exporter_org_outside_collaborators",
+		"Number of distinct outside collaborators across all repositories of an organization",
+		[]string{"org"},
+		nil,
+	)
+
+	organizationTeams = prometheus.NewDesc(
+		"github_exporter_org_teams",
+		"Number of teams in an organization",
+		[]string{"org"},
+		nil,
+	)
+
+	organizationTeamMembers = prometheus.NewDesc(
+		"github_exporter_org_team_members",
+		"Number of direct members of a team",
+		[]string{"org", "team"},
+		nil,
+	)
+
+	organizationFetchedAt = prometheus.NewDesc(
+		"github_exporter_org_fetched_at",
+		"UNIX timestamp of an organization's last fetch time (when it was retrieved from the API)",
+		[]string{"org"},
+		nil,
+	)
+
 	//////////////////////////////////////////////
 	// exporter-related
 
@@ -246,6 +695,20 @@ var (
 		nil,
 	)
 
+	githubRESTRequestsRemaining = prometheus.NewDesc(
+		"github_exporter_api_rest_requests_remaining",
+		"Number of currently remaining GitHub REST API requests",
+		nil,
+		nil,
+	)
+
+	suppressedItemSeries = prometheus.NewDesc(
+		"github_exporter_suppressed_item_series",
+		"Number of per-item series that were not reported because of the -item-series option",
+		[]string{"repo", "kind"},
+		nil,
+	)
+
 	githubRequestsTotal = prometheus.NewDesc(
 		"github_exporter_api_requests_total",
 		"Total number of requests against the GitHub API",
@@ -253,16 +716,37 @@ var (
 		nil,
 	)
 
+	githubLabelFollowUpItems = prometheus.NewDesc(
+		"github_exporter_api_label_follow_up_items",
+		"Number of issues/PRs whose labels required additional API requests to fetch",
+		[]string{"repo"},
+		nil,
+	)
+
 	githubCostsTotal = prometheus.NewDesc(
 		"github_exporter_api_costs_total",
 		"Total sum of API credits spent for all performed API requests",
 		[]string{"repo"},
 		nil,
 	)
+
+	githubTargetRequestsTotal = prometheus.NewDesc(
+		"github_exporter_api_target_requests_total",
+		"Total number of requests against the GitHub API that are not tied to a repository",
+		[]string{"kind", "target"},
+		nil,
+	)
+
+	githubTargetCostsTotal = prometheus.NewDesc(
+		"github_exporter_api_target_costs_total",
+		"Total sum of API credits spent for requests that are not tied to a repository",
+		[]string{"kind", "target"},
+		nil,
+	)
 )
 
 func init() {
-	prLabels := []string{"repo", "number", "author", "state"}
+	prLabels := []string{"repo", "number", "author", "author_type", "state", "assigned", "milestone"}
 	prLabels = append(prLabels, prow.PullRequestLabelNames()...)
 
 	pullRequestInfo = prometheus.NewDesc(
@@ -272,7 +756,7 @@ func init() {
 		nil,
 	)
 
-	issueLabels := []string{"repo", "number", "author", "state"}
+	issueLabels := []string{"repo", "number", "author", "author_type", "state", "state_reason", "assigned", "has_linked_pr", "milestone"}
 	issueLabels = append(issueLabels, prow.IssueLabelNames()...)
 
 	issueInfo = prometheus.NewDesc(
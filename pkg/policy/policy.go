@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package policy implements SLO-like rules for issues and pull requests,
+// e.g. "open PRs labelled priority/critical must be updated within 24h".
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+)
+
+const (
+	KindIssue       = "issue"
+	KindPullRequest = "pullrequest"
+)
+
+// Duration is a time.Duration that is encoded as a string like "24h" in JSON.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	d.Duration = parsed
+
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Policy selects issues and/or PRs and defines thresholds for them. Every
+// selected item that exceeds one of the thresholds violates the policy.
+type Policy struct {
+	Name string `json:"name"`
+
+	// Kind is either "issue" or "pullrequest"; if empty, both are selected.
+	Kind string `json:"kind,omitempty"`
+
+	// States are the lowercased item states (e.g. "open"); if empty, all
+	// states are selected.
+	States []string `json:"states,omitempty"`
+
+	// Labels are label patterns (e.g. "priority/critical" or "kind/*") that
+	// must all be matched by at least one of the item's labels.
+	Labels []string `json:"labels,omitempty"`
+
+	// WithoutLabels are label patterns that must not match any of the
+	// item's labels.
+	WithoutLabels []string `json:"withoutLabels,omitempty"`
+
+	// MaxAge is the maximum time since the item was created.
+	MaxAge *Duration `json:"maxAge,omitempty"`
+
+	// MaxIdle is the maximum time since the item was last updated.
+	MaxIdle *Duration `json:"maxIdle,omitempty"`
+}
+
+func (p *Policy) Validate() error {
+	if p.Name == "" {
+		return errors.New("no name given")
+	}
+
+	if p.Kind != "" && p.Kind != KindIssue && p.Kind != KindPullRequest {
+		return fmt.Errorf("invalid kind %q, must be %q or %q", p.Kind, KindIssue, KindPullRequest)
+	}
+
+	for _, pattern := range append(append([]string{}, p.Labels...), p.WithoutLabels...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid label pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// item is the common view on issues and PRs.
+type item struct {
+	kind      string
+	state     string
+	labels    []string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func (p *Policy) ViolatedByIssue(issue *github.Issue, now time.Time) bool {
+	return p.violatedBy(item{
+		kind:      KindIssue,
+		state:     string(issue.State),
+		labels:    issue.Labels,
+		createdAt: issue.CreatedAt,
+		updatedAt: issue.UpdatedAt,
+	}, now)
+}
+
+func (p *Policy) ViolatedByPullRequest(pr *github.PullRequest, now time.Time) bool {
+	return p.violatedBy(item{
+		kind:      KindPullRequest,
+		state:     string(pr.State),
+		labels:    pr.Labels,
+		createdAt: pr.CreatedAt,
+		updatedAt: pr.UpdatedAt,
+	}, now)
+}
+
+func (p *Policy) violatedBy(i item, now time.Time) bool {
+	if !p.selects(i) {
+		return false
+	}
+
+	if p.MaxAge != nil && now.Sub(i.createdAt) > p.MaxAge.Duration {
+		return true
+	}
+
+	if p.MaxIdle != nil && now.Sub(i.updatedAt) > p.MaxIdle.Duration {
+		return true
+	}
+
+	// without any thresholds, every selected item is a violation
+	return p.MaxAge == nil && p.MaxIdle == nil
+}
+
+func (p *Policy) selects(i item) bool {
+	if p.Kind != "" && p.Kind != i.kind {
+		return false
+	}
+
+	if len(p.States) > 0 && !containsFold(p.States, i.state) {
+		return false
+	}
+
+	for _, pattern := range p.Labels {
+		if !matchesAny(pattern, i.labels) {
+			return false
+		}
+	}
+
+	for _, pattern := range p.WithoutLabels {
+		if matchesAny(pattern, i.labels) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsFold(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if strings.EqualFold(s, needle) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAny(pattern string, labels []string) bool {
+	pattern = strings.ToLower(pattern)
+
+	for _, label := range labels {
+		if matched, _ := path.Match(pattern, strings.ToLower(label)); matched {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+package policy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicyViolatedBy(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := &Duration{Duration: 24 * time.Hour}
+
+	openBug := item{
+		kind:      KindIssue,
+		state:     "OPEN",
+		labels:    []string{"kind/bug", "Priority/Critical"},
+		createdAt: now.Add(-48 * time.Hour),
+		updatedAt: now.Add(-time.Hour),
+	}
+
+	testcases := []struct {
+		name     string
+		policy   Policy
+		item     item
+		expected bool
+	}{
+		{
+			name:     "no selectors and no thresholds select everything",
+			policy:   Policy{Name: "all"},
+			item:     openBug,
+			expected: true,
+		},
+		{
+			name:     "kind mismatch",
+			policy:   Policy{Name: "prs", Kind: KindPullRequest},
+			item:     openBug,
+			expected: false,
+		},
+		{
+			name:     "states are compared case-insensitively",
+			policy:   Policy{Name: "open", States: []string{"open"}},
+			item:     openBug,
+			expected: true,
+		},
+		{
+			name:     "state mismatch",
+			policy:   Policy{Name: "closed", States: []string{"closed", "merged"}},
+			item:     openBug,
+			expected: false,
+		},
+		{
+			name:     "label glob matches",
+			policy:   Policy{Name: "bugs", Labels: []string{"kind/*"}},
+			item:     openBug,
+			expected: true,
+		},
+		{
+			name:     "labels are matched case-insensitively",
+			policy:   Policy{Name: "critical", Labels: []string{"priority/critical"}},
+			item:     openBug,
+			expected: true,
+		},
+		{
+			name:     "all label patterns must match",
+			policy:   Policy{Name: "bugs", Labels: []string{"kind/*", "area/*"}},
+			item:     openBug,
+			expected: false,
+		},
+		{
+			name:     "excluded label glob",
+			policy:   Policy{Name: "no-prio", WithoutLabels: []string{"priority/*"}},
+			item:     openBug,
+			expected: false,
+		},
+		{
+			name:     "max age exceeded",
+			policy:   Policy{Name: "age", MaxAge: day},
+			item:     openBug,
+			expected: true,
+		},
+		{
+			name:     "max idle not exceeded",
+			policy:   Policy{Name: "idle", MaxIdle: day},
+			item:     openBug,
+			expected: false,
+		},
+		{
+			name:     "any exceeded threshold is a violation",
+			policy:   Policy{Name: "both", MaxAge: day, MaxIdle: day},
+			item:     openBug,
+			expected: true,
+		},
+		{
+			name:     "thresholds do not apply to unselected items",
+			policy:   Policy{Name: "prs", Kind: KindPullRequest, MaxAge: day},
+			item:     openBug,
+			expected: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if violated := testcase.policy.violatedBy(testcase.item, now); violated != testcase.expected {
+				t.Fatalf("Expected violatedBy to return %v, got %v.", testcase.expected, violated)
+			}
+		})
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		policy  Policy
+		invalid bool
+	}{
+		{
+			name:   "minimal policy",
+			policy: Policy{Name: "minimal"},
+		},
+		{
+			name:    "missing name",
+			policy:  Policy{},
+			invalid: true,
+		},
+		{
+			name:    "invalid kind",
+			policy:  Policy{Name: "kind", Kind: "discussion"},
+			invalid: true,
+		},
+		{
+			name:    "invalid label pattern",
+			policy:  Policy{Name: "labels", WithoutLabels: []string{"kind/["}},
+			invalid: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			err := testcase.policy.Validate()
+			if testcase.invalid && err == nil {
+				t.Fatal("Expected an error, but got none.")
+			}
+			if !testcase.invalid && err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+		})
+	}
+}
@@ -39,6 +39,16 @@ func IssueLabels(issue *github.Issue) []string {
 	}
 }
 
+// Kind returns the value of the kind/* label, or an empty string.
+func Kind(labels []string) string {
+	return prefixedLabel("kind", labels)
+}
+
+// Priority returns the value of the priority/* label, or an empty string.
+func Priority(labels []string) string {
+	return prefixedLabel("priority", labels)
+}
+
 func prefixedLabel(prefix string, labels []string) string {
 	prefix = strings.ToLower(strings.TrimSuffix(prefix, "/"))
 	regex := regexp.MustCompile(fmt.Sprintf(`^%s/(.+)$`, prefix))
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2023 Christoph Mewes
+// SPDX-License-Identifier: MIT
+
+// Package state persists data that cannot be re-fetched from GitHub, like
+// milestone burndowns, across restarts.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"go.xrstf.de/github_exporter/pkg/github"
+)
+
+type State struct {
+	Repositories map[string]RepositoryState `json:"repositories"`
+}
+
+type RepositoryState struct {
+	Burndowns map[int]github.Burndown `json:"burndowns,omitempty"`
+}
+
+func New() *State {
+	return &State{
+		Repositories: map[string]RepositoryState{},
+	}
+}
+
+// Load reads the state from the given file. A missing file is not an error
+// and results in an empty state.
+func Load(filename string) (*State, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return New(), nil
+		}
+
+		return nil, err
+	}
+
+	s := New()
+	if err := json.Unmarshal(content, s); err != nil {
+		return nil, err
+	}
+
+	if s.Repositories == nil {
+		s.Repositories = map[string]RepositoryState{}
+	}
+
+	return s, nil
+}
+
+// Save writes the state atomically to the given file.
+func (s *State) Save(filename string) error {
+	content, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), filename)
+}
+
+// Restore applies the persisted state to a repository.
+func (s *State) Restore(repo *github.Repository) {
+	if repoState, ok := s.Repositories[repo.FullName()]; ok && repoState.Burndowns != nil {
+		repo.SetBurndowns(repoState.Burndowns)
+	}
+}
+
+// Capture records the current state of a repository.
+func (s *State) Capture(repo *github.Repository) {
+	s.Repositories[repo.FullName()] = RepositoryState{
+		Burndowns: repo.GetBurndowns(),
+	}
+}
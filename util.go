@@ -4,9 +4,12 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type repository struct {
@@ -38,3 +41,140 @@ func (l *repositoryList) Set(value string) error {
 
 	return nil
 }
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type project struct {
+	owner  string
+	number int
+}
+
+type projectList []project
+
+func (l *projectList) String() string {
+	return fmt.Sprint(*l)
+}
+
+func (l *projectList) Set(value string) error {
+	parts := strings.Split(value, "/")
+
+	if len(parts) != 2 {
+		return errors.New(`not a valid project, must be "owner/number"`)
+	}
+
+	number, err := strconv.Atoi(parts[1])
+	if err != nil || number <= 0 {
+		return errors.New(`not a valid project number, must be "owner/number"`)
+	}
+
+	*l = append(*l, project{
+		owner:  parts[0],
+		number: number,
+	})
+
+	return nil
+}
+
+// intList is a comma-separated list of non-negative integers. Setting
+// it replaces any default values.
+type intList []int
+
+func (l *intList) String() string {
+	values := []string{}
+	for _, v := range *l {
+		values = append(values, strconv.Itoa(v))
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (l *intList) Set(value string) error {
+	result := intList{}
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 {
+			return fmt.Errorf("invalid value %q, must be a non-negative integer", part)
+		}
+
+		result = append(result, v)
+	}
+
+	*l = result
+
+	return nil
+}
+
+// durationList is a comma-separated list of positive durations. Setting
+// it replaces any default values.
+type durationList []time.Duration
+
+func (l *durationList) String() string {
+	values := []string{}
+	for _, v := range *l {
+		values = append(values, v.String())
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (l *durationList) Set(value string) error {
+	result := durationList{}
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		v, err := time.ParseDuration(part)
+		if err != nil || v <= 0 {
+			return fmt.Errorf("invalid value %q, must be a positive duration", part)
+		}
+
+		result = append(result, v)
+	}
+
+	*l = result
+
+	return nil
+}
+
+// Seconds returns the durations in seconds, e.g. for histogram buckets.
+func (l durationList) Seconds() []float64 {
+	result := []float64{}
+	for _, v := range l {
+		result = append(result, v.Seconds())
+	}
+
+	return result
+}
+
+// every calls fn in the given interval until the context is cancelled.
+func every(ctx context.Context, interval time.Duration, fn func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn()
+		}
+	}
+}